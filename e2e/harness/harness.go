@@ -5,9 +5,11 @@ package harness
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 )
@@ -122,8 +124,20 @@ func New(t *testing.T) *Harness {
 // Run executes a CLI command and returns the result.
 func (h *Harness) Run(args ...string) *Result {
 	h.t.Helper()
+	return h.run(nil, args)
+}
+
+// RunWithStdin executes a CLI command with stdin as its standard input.
+func (h *Harness) RunWithStdin(stdin string, args ...string) *Result {
+	h.t.Helper()
+	return h.run(strings.NewReader(stdin), args)
+}
+
+func (h *Harness) run(stdin io.Reader, args []string) *Result {
+	h.t.Helper()
 
 	cmd := exec.Command(h.BinaryPath, args...)
+	cmd.Stdin = stdin
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
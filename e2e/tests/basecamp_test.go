@@ -149,6 +149,51 @@ func TestCards(t *testing.T) {
 			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
 		}
 	})
+
+	t.Run("cards include rich fields", func(t *testing.T) {
+		result := h.Run("cards", h.ProjectID, h.BoardID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		columns, ok := result.GetNested("columns").([]any)
+		if !ok || len(columns) == 0 {
+			t.Skip("no cards on board")
+		}
+
+		cards, ok := columns[0].(map[string]any)["cards"].([]any)
+		if !ok || len(cards) == 0 {
+			t.Skip("no cards in first column")
+		}
+
+		card := cards[0].(map[string]any)
+		for _, key := range []string{"steps_completed", "steps_total", "comments_count", "completed"} {
+			if _, ok := card[key]; !ok {
+				t.Errorf("expected %s in card output", key)
+			}
+		}
+	})
+
+	t.Run("filter and sort", func(t *testing.T) {
+		result := h.Run("cards", h.ProjectID, h.BoardID, "--mine", "--sort", "due")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetNested("columns") == nil {
+			t.Error("expected columns in response")
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		result := h.Run("cards", h.ProjectID, h.BoardID, "--sort", "random")
+
+		if result.Success() {
+			t.Error("expected failure with invalid --sort")
+		}
+	})
 }
 
 func TestCard(t *testing.T) {
@@ -2,6 +2,9 @@ package tests
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -62,8 +65,165 @@ func TestCampfirePost(t *testing.T) {
 			t.Error("expected failure without --content flag")
 		}
 
-		if result.ErrorMessage() != "--content required" {
-			t.Errorf("expected '--content required' error, got: %s", result.ErrorMessage())
+		if result.ErrorMessage() != "--content, --content-file, --stdin or --attach required" {
+			t.Errorf("expected '--content, --content-file, --stdin or --attach required' error, got: %s", result.ErrorMessage())
+		}
+	})
+}
+
+func TestCampfireSince(t *testing.T) {
+	h := harness.New(t)
+
+	var lineID int
+
+	t.Run("post a line", func(t *testing.T) {
+		result := h.Run("campfire-post", h.ProjectID, "--content", fmt.Sprintf("E2E Since %d", time.Now().UnixNano()))
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		lineID = result.GetInt("id")
+	})
+
+	t.Run("since a line id", func(t *testing.T) {
+		if lineID == 0 {
+			t.Skip("no line posted")
+		}
+
+		result := h.Run("campfire", h.ProjectID, "--since", fmt.Sprintf("%d", lineID-1))
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("last_line_id") < lineID {
+			t.Errorf("expected last_line_id >= %d, got %d", lineID, result.GetInt("last_line_id"))
+		}
+	})
+
+	t.Run("since a time", func(t *testing.T) {
+		result := h.Run("campfire", h.ProjectID, "--since", "-1h")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		result := h.Run("campfire", h.ProjectID, "--since", "whenever")
+
+		if result.Success() {
+			t.Error("expected failure for invalid --since")
+		}
+	})
+}
+
+func TestCampfireLines(t *testing.T) {
+	h := harness.New(t)
+
+	var lineID string
+
+	t.Run("post from stdin", func(t *testing.T) {
+		content := fmt.Sprintf("E2E Build Log %d\nstep 1 ok\nstep 2 ok", time.Now().UnixNano())
+		result := h.RunWithStdin(content, "campfire-post", h.ProjectID, "--stdin")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		lineID = fmt.Sprintf("%d", result.GetInt("id"))
+		if lineID == "0" {
+			t.Fatal("expected line id in response")
+		}
+	})
+
+	t.Run("view line", func(t *testing.T) {
+		if lineID == "" {
+			t.Skip("no line posted")
+		}
+
+		result := h.Run("campfire-line", h.ProjectID, lineID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if fmt.Sprintf("%d", result.GetInt("id")) != lineID {
+			t.Errorf("expected id %s, got %d", lineID, result.GetInt("id"))
+		}
+	})
+
+	t.Run("delete line", func(t *testing.T) {
+		if lineID == "" {
+			t.Skip("no line posted")
+		}
+
+		result := h.Run("campfire-delete", h.ProjectID, lineID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+	})
+
+	t.Run("post with file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "report.txt")
+		if err := os.WriteFile(file, []byte("all green"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result := h.Run("campfire-post", h.ProjectID, "--content", "Nightly report", "--file", file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("attachable_sgid") == "" {
+			t.Error("expected attachable_sgid in response")
+		}
+
+		if id := result.GetInt("id"); id != 0 {
+			h.Run("campfire-delete", h.ProjectID, fmt.Sprintf("%d", id))
+		}
+	})
+
+	t.Run("post markdown from a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "notes.md")
+		if err := os.WriteFile(file, []byte("**E2E release notes**\n\n- fixed things"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result := h.Run("campfire-post", h.ProjectID, "--content-file", file, "--markdown")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		if !strings.Contains(result.GetString("content"), "<strong>E2E release notes</strong>") {
+			t.Errorf("expected markdown converted to HTML, got: %s", result.GetString("content"))
+		}
+
+		if id := result.GetInt("id"); id != 0 {
+			h.Run("campfire-delete", h.ProjectID, fmt.Sprintf("%d", id))
+		}
+	})
+
+	t.Run("piped log with @names", func(t *testing.T) {
+		content := fmt.Sprintf("E2E Build Log %d\nnpm i @types/node\nnotify @here", time.Now().UnixNano())
+		result := h.RunWithStdin(content, "campfire-post", h.ProjectID, "--stdin")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if id := result.GetInt("id"); id != 0 {
+			h.Run("campfire-delete", h.ProjectID, fmt.Sprintf("%d", id))
+		}
+	})
+
+	t.Run("content and stdin", func(t *testing.T) {
+		result := h.RunWithStdin("x", "campfire-post", h.ProjectID, "--content", "y", "--stdin")
+
+		if result.Success() {
+			t.Error("expected failure combining --content and --stdin")
 		}
 	})
 }
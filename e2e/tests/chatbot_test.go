@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/rzolkos/basecamp-cli/e2e/harness"
+)
+
+func TestChatbots(t *testing.T) {
+	h := harness.New(t)
+
+	t.Run("list chatbots", func(t *testing.T) {
+		result := h.Run("chatbots", h.ProjectID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if _, ok := result.JSON["chatbots"]; !ok {
+			t.Error("expected chatbots array in response")
+		}
+	})
+
+	t.Run("post without a bot", func(t *testing.T) {
+		result := h.Run("chatbot-post", "--content", "hello")
+
+		if result.Success() {
+			t.Error("expected failure without --bot or --bot-url")
+		}
+	})
+
+	t.Run("post with an unknown bot", func(t *testing.T) {
+		result := h.Run("chatbot-post", "--bot", "no-such-bot-e2e", "--content", "hello")
+
+		if result.Success() {
+			t.Error("expected failure for an unknown bot")
+		}
+	})
+
+	t.Run("create without name", func(t *testing.T) {
+		result := h.Run("chatbot-create", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without --name")
+		}
+	})
+}
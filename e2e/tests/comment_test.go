@@ -2,6 +2,9 @@ package tests
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,6 +56,65 @@ func TestCommentAdd(t *testing.T) {
 		}
 	})
 
+	t.Run("mention by email", func(t *testing.T) {
+		profile := h.Run("my-profile")
+		if !profile.Success() || profile.GetString("email") == "" {
+			t.Skip("could not read own email")
+		}
+
+		result := h.Run("comment-add", h.ProjectID, todoID, "--content", "Note for @"+profile.GetString("email"))
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		view := h.Run("comment", h.ProjectID, fmt.Sprintf("%d", result.GetInt("id")))
+		if !strings.Contains(view.GetString("content"), "@"+profile.GetString("name")) {
+			t.Errorf("expected mention of %s, got %q", profile.GetString("name"), view.GetString("content"))
+		}
+	})
+
+	t.Run("add comment with attachments", func(t *testing.T) {
+		dir := t.TempDir()
+		files := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}
+		for _, file := range files {
+			if err := os.WriteFile(file, []byte("attached"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		result := h.Run("comment-add", h.ProjectID, todoID, "--content", "Files", "--attach", files[0], "--attach", files[1])
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		sgids, _ := result.JSON["attachable_sgids"].([]any)
+		if len(sgids) != 2 {
+			t.Errorf("expected 2 attachable_sgids, got: %s", result.Stdout)
+		}
+	})
+
+	t.Run("missing attachment", func(t *testing.T) {
+		result := h.Run("comment-add", h.ProjectID, todoID, "--content", "x", "--attach", filepath.Join(t.TempDir(), "missing.txt"))
+
+		if result.Success() {
+			t.Error("expected failure for a missing attachment")
+		}
+	})
+
+	t.Run("unknown mention", func(t *testing.T) {
+		result := h.Run("comment-add", h.ProjectID, todoID, "--content", "Hi @nobody-e2e-unknown-person")
+
+		if result.Success() {
+			t.Error("expected failure for unknown mention")
+		}
+
+		if !strings.Contains(result.ErrorMessage(), "no person matching") {
+			t.Errorf("expected unknown person error, got: %s", result.ErrorMessage())
+		}
+	})
+
 	t.Run("missing content flag", func(t *testing.T) {
 		result := h.Run("comment-add", h.ProjectID, todoID)
 
@@ -64,4 +126,86 @@ func TestCommentAdd(t *testing.T) {
 			t.Errorf("expected '--content required' error, got: %s", result.ErrorMessage())
 		}
 	})
+	var commentID string
+
+	t.Run("add markdown comment from stdin", func(t *testing.T) {
+		result := h.RunWithStdin("**Bold** line\n\n- one\n- two\n", "comment-add", h.ProjectID, todoID, "--stdin", "--markdown")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		commentID = fmt.Sprintf("%d", result.GetInt("id"))
+	})
+
+	t.Run("list comments", func(t *testing.T) {
+		result := h.Run("comments", h.ProjectID, todoID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		comments, ok := result.JSON["comments"].([]any)
+		if !ok || len(comments) < 2 {
+			t.Errorf("expected at least 2 comments, got: %s", result.Stdout)
+		}
+	})
+
+	t.Run("list comments since the future", func(t *testing.T) {
+		result := h.Run("comments", h.ProjectID, todoID, "--since", time.Now().Add(time.Hour).Format(time.RFC3339))
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if comments, _ := result.JSON["comments"].([]any); len(comments) != 0 {
+			t.Errorf("expected no comments, got %d", len(comments))
+		}
+	})
+
+	t.Run("view comment", func(t *testing.T) {
+		if commentID == "" || commentID == "0" {
+			t.Skip("no comment created")
+		}
+		result := h.Run("comment", h.ProjectID, commentID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("parent_id") == 0 {
+			t.Error("expected parent_id in response")
+		}
+	})
+
+	t.Run("update comment", func(t *testing.T) {
+		if commentID == "" || commentID == "0" {
+			t.Skip("no comment created")
+		}
+		result := h.Run("comment-update", h.ProjectID, commentID, "--content", "Updated", "--markdown")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		view := h.Run("comment", h.ProjectID, commentID)
+		if view.GetString("content") != "Updated" {
+			t.Errorf("expected content=Updated, got %s", view.GetString("content"))
+		}
+	})
+
+	t.Run("trash comment", func(t *testing.T) {
+		if commentID == "" || commentID == "0" {
+			t.Skip("no comment created")
+		}
+		result := h.Run("comment-trash", h.ProjectID, commentID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status=ok")
+		}
+	})
 }
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -100,4 +101,97 @@ func TestMessageCRUD(t *testing.T) {
 			t.Error("expected id in response")
 		}
 	})
+
+	t.Run("view message body formats", func(t *testing.T) {
+		if messageID == "" {
+			t.Skip("no message created")
+		}
+
+		html := h.Run("message", h.ProjectID, messageID, "--body", "html")
+		if !html.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", html.ExitCode, html.Stderr)
+		}
+		if !strings.Contains(html.GetString("content"), "<") {
+			t.Errorf("expected html content, got %q", html.GetString("content"))
+		}
+
+		text := h.Run("message", h.ProjectID, messageID, "--body", "text")
+		if text.GetString("content") != "Test message content from e2e tests" {
+			t.Errorf("expected text content, got %q", text.GetString("content"))
+		}
+	})
+
+	t.Run("invalid body format", func(t *testing.T) {
+		result := h.Run("message", h.ProjectID, "1", "--body", "rtf")
+
+		if result.Success() {
+			t.Error("expected failure with invalid --body")
+		}
+	})
+}
+
+func TestMessageUpdatePin(t *testing.T) {
+	h := harness.New(t)
+
+	var messageID string
+	messageSubject := fmt.Sprintf("E2E Draft Message %d", time.Now().UnixNano())
+
+	t.Run("create draft", func(t *testing.T) {
+		result := h.Run("message-create", h.ProjectID, "--subject", messageSubject, "--status", "drafted")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		messageID = fmt.Sprintf("%d", result.GetInt("id"))
+		if messageID == "0" {
+			t.Fatal("expected message id in response")
+		}
+	})
+
+	t.Run("update message", func(t *testing.T) {
+		if messageID == "" {
+			t.Skip("no message created")
+		}
+
+		result := h.Run("message-update", h.ProjectID, messageID, "--subject", messageSubject+" (edited)")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("subject") != messageSubject+" (edited)" {
+			t.Errorf("expected updated subject, got %s", result.GetString("subject"))
+		}
+	})
+
+	t.Run("pin and unpin", func(t *testing.T) {
+		if messageID == "" {
+			t.Skip("no message created")
+		}
+
+		for _, cmd := range []string{"message-pin", "message-unpin"} {
+			result := h.Run(cmd, h.ProjectID, messageID)
+
+			if !result.Success() {
+				t.Fatalf("%s: expected success, got exit code %d\nstderr: %s", cmd, result.ExitCode, result.Stderr)
+			}
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		result := h.Run("message-create", h.ProjectID, "--subject", "x", "--status", "archived")
+
+		if result.Success() {
+			t.Error("expected failure for invalid --status")
+		}
+	})
+
+	t.Run("update without changes", func(t *testing.T) {
+		result := h.Run("message-update", h.ProjectID, "1")
+
+		if result.Success() {
+			t.Error("expected failure without any flags")
+		}
+	})
 }
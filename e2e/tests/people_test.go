@@ -166,3 +166,38 @@ func TestPerson(t *testing.T) {
 		}
 	})
 }
+
+func TestAssignments(t *testing.T) {
+	h := harness.New(t)
+
+	t.Run("my todos", func(t *testing.T) {
+		result := h.Run("my-todos", "--due-within", "30d")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("person_id") == 0 {
+			t.Error("expected person_id in response")
+		}
+		if _, ok := result.JSON["projects"]; !ok {
+			t.Error("expected projects array in response")
+		}
+	})
+
+	t.Run("assigned requires person", func(t *testing.T) {
+		result := h.Run("assigned")
+
+		if result.Success() {
+			t.Error("expected failure without --person")
+		}
+	})
+
+	t.Run("invalid due-within", func(t *testing.T) {
+		result := h.Run("my-todos", "--due-within", "soon")
+
+		if result.Success() {
+			t.Error("expected failure for invalid --due-within")
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/e2e/harness"
+)
+
+func TestRecurring(t *testing.T) {
+	h := harness.New(t)
+
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "recurring.yml")
+	stateFile := filepath.Join(dir, "state.json")
+
+	rules := `rules:
+  - name: e2e-daily
+    project: ` + h.ProjectID + `
+    todolist: ` + h.TodolistID + `
+    rrule: FREQ=DAILY
+    start: ` + time.Now().AddDate(0, 0, -7).Format("2006-01-02") + `
+    content: "E2E recurring {{date}}"
+    due_offset: 1d
+`
+	if err := os.WriteFile(rulesFile, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list rules", func(t *testing.T) {
+		result := h.Run("recurring", "list", "--rules", rulesFile, "--state", stateFile)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if len(result.JSONArray) != 1 {
+			t.Fatalf("expected 1 rule, got %d", len(result.JSONArray))
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		result := h.Run("recurring", "run", "--dry-run", "--rules", rulesFile, "--state", stateFile)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		if len(created) != 1 {
+			t.Errorf("expected today's occurrence only, got %d", len(created))
+		}
+		if _, err := os.Stat(stateFile); err == nil {
+			t.Error("dry run should not write the state file")
+		}
+	})
+
+	t.Run("run is idempotent", func(t *testing.T) {
+		result := h.Run("recurring", "run", "--rules", rulesFile, "--state", stateFile)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		if len(created) != 1 {
+			t.Fatalf("expected 1 todo created, got %d", len(created))
+		}
+		if item, _ := created[0].(map[string]any); item != nil {
+			if id, _ := item["todo_id"].(float64); id > 0 {
+				defer h.Run("todo-trash", h.ProjectID, fmt.Sprintf("%.0f", id))
+			}
+		}
+
+		again := h.Run("recurring", "run", "--rules", rulesFile, "--state", stateFile)
+		if !again.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", again.ExitCode, again.Stderr)
+		}
+		if created, _ := again.JSON["created"].([]any); len(created) != 0 {
+			t.Errorf("expected no todos on second run, got %d", len(created))
+		}
+	})
+
+	t.Run("missing subcommand", func(t *testing.T) {
+		result := h.Run("recurring")
+
+		if result.Success() {
+			t.Error("expected failure without subcommand")
+		}
+	})
+}
@@ -2,6 +2,9 @@ package tests
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -118,3 +121,245 @@ func TestEventCRUD(t *testing.T) {
 		}
 	})
 }
+
+func TestEventUpdateTrash(t *testing.T) {
+	h := harness.New(t)
+
+	var eventID string
+
+	t.Run("create with natural dates and recurrence", func(t *testing.T) {
+		result := h.Run("event-create", h.ProjectID,
+			"--summary", fmt.Sprintf("E2E Repeating Event %d", time.Now().UnixNano()),
+			"--starts-at", "tomorrow 14:00",
+			"--ends-at", "+30m",
+			"--repeat", "weekly",
+			"--repeat-until", "+4w",
+			"--no-notify")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		eventID = fmt.Sprintf("%d", result.GetInt("id"))
+		if eventID == "0" {
+			t.Fatal("expected event id in response")
+		}
+	})
+
+	t.Run("update event", func(t *testing.T) {
+		if eventID == "" {
+			t.Skip("no event created")
+		}
+
+		result := h.Run("event-update", h.ProjectID, eventID, "--summary", "E2E Updated Event", "--starts-at", "+2d", "--no-repeat")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("summary") != "E2E Updated Event" {
+			t.Errorf("expected updated summary, got %q", result.GetString("summary"))
+		}
+	})
+
+	t.Run("trash event", func(t *testing.T) {
+		if eventID == "" {
+			t.Skip("no event created")
+		}
+
+		result := h.Run("event-trash", h.ProjectID, eventID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status=ok")
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		result := h.Run("event-create", h.ProjectID, "--summary", "Bad", "--starts-at", "whenever", "--ends-at", "+1h")
+
+		if result.Success() {
+			t.Error("expected failure for unparseable date")
+		}
+	})
+
+	t.Run("update requires entry id", func(t *testing.T) {
+		result := h.Run("event-update", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without entry_id")
+		}
+	})
+}
+
+func TestScheduleFilters(t *testing.T) {
+	h := harness.New(t)
+
+	t.Run("date range", func(t *testing.T) {
+		result := h.Run("schedule", h.ProjectID, "--from", "today", "--to", "+30d")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if _, ok := result.JSON["entries"]; !ok {
+			t.Error("expected entries array in response")
+		}
+	})
+
+	t.Run("upcoming", func(t *testing.T) {
+		result := h.Run("schedule", h.ProjectID, "--upcoming", "2")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		entries, _ := result.JSON["entries"].([]any)
+		if len(entries) > 2 {
+			t.Errorf("expected at most 2 entries, got %d", len(entries))
+		}
+	})
+
+	t.Run("all projects this week", func(t *testing.T) {
+		result := h.Run("schedule", "--all-projects", "--from", "monday", "--to", "sunday")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("from") == "" || result.GetString("to") == "" {
+			t.Error("expected from and to in response")
+		}
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		result := h.Run("schedule", h.ProjectID, "--archived")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+	})
+
+	t.Run("invalid upcoming", func(t *testing.T) {
+		result := h.Run("schedule", h.ProjectID, "--upcoming", "zero")
+
+		if result.Success() {
+			t.Error("expected failure for invalid --upcoming")
+		}
+	})
+}
+
+func TestScheduleICS(t *testing.T) {
+	h := harness.New(t)
+
+	t.Run("export to stdout", func(t *testing.T) {
+		result := h.Run("schedule-export", h.ProjectID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if !strings.HasPrefix(result.Stdout, "BEGIN:VCALENDAR") {
+			t.Errorf("expected an iCalendar file, got: %s", result.Stdout)
+		}
+	})
+
+	t.Run("export to file", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "schedule.ics")
+		result := h.Run("schedule-export", h.ProjectID, "--ics", out, "--include-todos", "--include-cards")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("file") != out {
+			t.Errorf("expected file=%s, got %s", out, result.GetString("file"))
+		}
+		if _, err := os.Stat(out); err != nil {
+			t.Errorf("expected export file: %v", err)
+		}
+	})
+
+	uid := fmt.Sprintf("e2e-%d@example.com", time.Now().UnixNano())
+	start := time.Now().Add(48 * time.Hour).UTC()
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"SUMMARY:E2E Imported Event",
+		"DTSTART:" + start.Format("20060102T150405Z"),
+		"DTEND:" + start.Add(time.Hour).Format("20060102T150405Z"),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	file := filepath.Join(t.TempDir(), "import.ics")
+	if err := os.WriteFile(file, []byte(calendar), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var entryID string
+
+	t.Run("import dry run", func(t *testing.T) {
+		result := h.Run("schedule-import", h.ProjectID, file, "--dry-run")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("created") != 1 {
+			t.Errorf("expected 1 event to create, got %d", result.GetInt("created"))
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		result := h.Run("schedule-import", h.ProjectID, file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		events, _ := result.JSON["events"].([]any)
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(events))
+		}
+		event, _ := events[0].(map[string]any)
+		if id, ok := event["id"].(float64); ok {
+			entryID = fmt.Sprintf("%d", int(id))
+		}
+	})
+
+	t.Run("re-import does not duplicate", func(t *testing.T) {
+		if entryID == "" {
+			t.Skip("no event imported")
+		}
+
+		result := h.Run("schedule-import", h.ProjectID, file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("created") != 0 {
+			t.Errorf("expected no new events, got %d", result.GetInt("created"))
+		}
+	})
+
+	t.Run("cleanup", func(t *testing.T) {
+		if entryID == "" {
+			t.Skip("no event imported")
+		}
+		h.Run("event-trash", h.ProjectID, entryID)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		result := h.Run("schedule-import", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without a file")
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rzolkos/basecamp-cli/e2e/harness"
+)
+
+func TestBoardSnapshot(t *testing.T) {
+	h := harness.New(t)
+
+	if h.BoardID == "" {
+		t.Skip("BASECAMP_TEST_BOARD_ID not set")
+	}
+
+	snapshot := filepath.Join(t.TempDir(), "snap.json")
+
+	t.Run("snapshot to file", func(t *testing.T) {
+		result := h.Run("board-snapshot", h.ProjectID, h.BoardID, "--out", snapshot)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status=ok")
+		}
+		if result.GetInt("columns") == 0 {
+			t.Error("expected at least one column")
+		}
+	})
+
+	t.Run("snapshot to stdout", func(t *testing.T) {
+		result := h.Run("board-snapshot", h.ProjectID, h.BoardID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("board_id") == 0 {
+			t.Error("expected board_id in snapshot")
+		}
+		if _, ok := result.JSON["columns"].([]any); !ok {
+			t.Error("expected columns in snapshot")
+		}
+	})
+
+	t.Run("diff against itself", func(t *testing.T) {
+		result := h.Run("board-diff", snapshot, snapshot)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		for _, key := range []string{"added", "removed", "moved", "retitled", "reassigned"} {
+			changes, ok := result.JSON[key].([]any)
+			if !ok || len(changes) != 0 {
+				t.Errorf("expected empty %s, got %v", key, result.JSON[key])
+			}
+		}
+	})
+
+	t.Run("diff against live with summary", func(t *testing.T) {
+		result := h.Run("board-diff", snapshot, "live", "--summary")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if !strings.Contains(result.Stdout, "changes from") {
+			t.Errorf("expected summary output, got: %s", result.Stdout)
+		}
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		result := h.Run("board-diff", snapshot)
+
+		if result.Success() {
+			t.Error("expected failure with one snapshot")
+		}
+	})
+}
+
+func TestBoardMetrics(t *testing.T) {
+	h := harness.New(t)
+
+	if h.BoardID == "" {
+		t.Skip("BASECAMP_TEST_BOARD_ID not set")
+	}
+
+	t.Run("metrics as JSON and CSV", func(t *testing.T) {
+		dir := t.TempDir()
+		result := h.Run("board-metrics", h.ProjectID, h.BoardID, "--since", "2026-01-01", "--csv", dir)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		for _, key := range []string{"time_in_state", "lead_time", "cycle_time", "throughput", "wip"} {
+			if _, ok := result.JSON[key]; !ok {
+				t.Errorf("expected %s in response", key)
+			}
+		}
+
+		for _, name := range []string{"time_in_state.csv", "throughput.csv", "wip.csv", "cards.csv"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("expected %s to be written: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		result := h.Run("board-metrics", h.ProjectID, h.BoardID, "--since", "last week")
+
+		if result.Success() {
+			t.Error("expected failure with invalid --since")
+		}
+	})
+
+	t.Run("unknown done column", func(t *testing.T) {
+		result := h.Run("board-metrics", h.ProjectID, h.BoardID, "--done", "No Such Column")
+
+		if result.Success() {
+			t.Error("expected failure with unknown --done column")
+		}
+	})
+}
@@ -2,6 +2,8 @@ package tests
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -105,6 +107,56 @@ func TestStepCRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("list steps", func(t *testing.T) {
+		if stepID == "" {
+			t.Skip("no step created")
+		}
+
+		result := h.Run("steps", h.ProjectID, h.CardID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetInt("total") == 0 {
+			t.Error("expected total > 0")
+		}
+		if _, ok := result.JSON["all_completed"]; !ok {
+			t.Error("expected all_completed in response")
+		}
+	})
+
+	t.Run("list pending steps", func(t *testing.T) {
+		result := h.Run("steps", h.ProjectID, h.CardID, "--pending")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		steps, _ := result.JSON["steps"].([]any)
+		for _, s := range steps {
+			if s.(map[string]any)["completed"] == true {
+				t.Error("expected only pending steps")
+			}
+		}
+	})
+
+	t.Run("delete step", func(t *testing.T) {
+		if stepID == "" {
+			t.Skip("no step created")
+		}
+
+		result := h.Run("step-delete", h.ProjectID, stepID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status=ok")
+		}
+	})
+
 	t.Run("missing title flag", func(t *testing.T) {
 		result := h.Run("step-create", h.ProjectID, h.CardID)
 
@@ -166,3 +218,54 @@ func TestStepReposition(t *testing.T) {
 		}
 	})
 }
+
+func TestStepImport(t *testing.T) {
+	h := harness.New(t)
+
+	if h.CardID == "" {
+		t.Skip("BASECAMP_TEST_CARD_ID not set")
+	}
+
+	suffix := time.Now().UnixNano()
+	checklist := fmt.Sprintf("# Checklist\n\n- [ ] E2E Import Step A %d\n- [x] E2E Import Step B %d due:2026-01-01\n", suffix, suffix)
+
+	file := filepath.Join(t.TempDir(), "checklist.md")
+	if err := os.WriteFile(file, []byte(checklist), 0644); err != nil {
+		t.Fatalf("failed to write checklist: %v", err)
+	}
+
+	t.Run("import steps", func(t *testing.T) {
+		result := h.Run("step-import", h.ProjectID, h.CardID, "--file", file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, ok := result.JSON["created"].([]any)
+		if !ok || len(created) != 2 {
+			t.Errorf("expected 2 created steps, got: %v", result.JSON["created"])
+		}
+	})
+
+	t.Run("re-import skips existing steps", func(t *testing.T) {
+		result := h.Run("step-import", h.ProjectID, h.CardID, "--file", file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		skipped, _ := result.JSON["skipped"].([]any)
+		if len(created) != 0 || len(skipped) != 2 {
+			t.Errorf("expected 0 created and 2 skipped, got %d and %d", len(created), len(skipped))
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		result := h.Run("step-import", h.ProjectID, h.CardID)
+
+		if result.Success() {
+			t.Error("expected failure without --file or --template")
+		}
+	})
+}
@@ -175,7 +175,74 @@ func TestTodoCRUD(t *testing.T) {
 		}
 	})
 
-	// Note: Basecamp API doesn't have a delete endpoint for todos,
-	// they can only be trashed via the recordings API which is out of scope.
-	// The test todo will remain in the todolist.
+	t.Run("update todo with dates", func(t *testing.T) {
+		if todoID == "" {
+			t.Skip("no todo created")
+		}
+
+		result := h.Run("todo-update", h.ProjectID, todoID, "--starts", "2026-01-01", "--due", "2026-01-31")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		view := h.Run("todo", h.ProjectID, todoID)
+		if view.GetString("due_on") != "2026-01-31" {
+			t.Errorf("expected due_on 2026-01-31, got %q", view.GetString("due_on"))
+		}
+		if view.GetString("starts_on") != "2026-01-01" {
+			t.Errorf("expected starts_on 2026-01-01, got %q", view.GetString("starts_on"))
+		}
+		if view.GetString("content") != todoContent {
+			t.Errorf("expected content to be kept, got %q", view.GetString("content"))
+		}
+	})
+
+	t.Run("clear due date", func(t *testing.T) {
+		if todoID == "" {
+			t.Skip("no todo created")
+		}
+
+		result := h.Run("todo-update", h.ProjectID, todoID, "--clear-due")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		view := h.Run("todo", h.ProjectID, todoID)
+		if view.GetString("due_on") != "" {
+			t.Errorf("expected due_on to be cleared, got %q", view.GetString("due_on"))
+		}
+		if view.GetString("starts_on") != "2026-01-01" {
+			t.Errorf("expected starts_on to be kept, got %q", view.GetString("starts_on"))
+		}
+	})
+
+	t.Run("update without flags", func(t *testing.T) {
+		if todoID == "" {
+			t.Skip("no todo created")
+		}
+
+		result := h.Run("todo-update", h.ProjectID, todoID)
+
+		if result.Success() {
+			t.Error("expected failure without update flags")
+		}
+	})
+
+	t.Run("trash todo", func(t *testing.T) {
+		if todoID == "" {
+			t.Skip("no todo created")
+		}
+
+		result := h.Run("todo-trash", h.ProjectID, todoID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status=ok")
+		}
+	})
 }
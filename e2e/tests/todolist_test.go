@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/e2e/harness"
+)
+
+func TestTodolistCRUD(t *testing.T) {
+	h := harness.New(t)
+
+	name := fmt.Sprintf("E2E list %d", time.Now().UnixNano())
+	var todolistID string
+
+	t.Run("create todolist", func(t *testing.T) {
+		result := h.Run("todolist-create", h.ProjectID, "--name", name, "--description", "Created by e2e tests")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status ok")
+		}
+		if result.GetInt("id") == 0 {
+			t.Error("expected id in response")
+		}
+		if result.GetString("title") != name {
+			t.Errorf("expected title %q, got %q", name, result.GetString("title"))
+		}
+
+		todolistID = fmt.Sprintf("%d", result.GetInt("id"))
+	})
+
+	t.Run("update todolist by name", func(t *testing.T) {
+		if todolistID == "" {
+			t.Skip("no todolist created")
+		}
+
+		result := h.Run("todolist-update", h.ProjectID, name, "--name", name+" renamed")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("title") != name+" renamed" {
+			t.Errorf("expected renamed title, got %q", result.GetString("title"))
+		}
+	})
+
+	t.Run("move todo into todolist", func(t *testing.T) {
+		if todolistID == "" {
+			t.Skip("no todolist created")
+		}
+
+		createResult := h.Run("todo-create", h.ProjectID, h.TodolistID, "--content", "Move test todo")
+		if !createResult.Success() {
+			t.Fatalf("failed to create todo: %s", createResult.Stderr)
+		}
+		todoID := fmt.Sprintf("%d", createResult.GetInt("id"))
+
+		result := h.Run("todo-move", h.ProjectID, todoID, "--to-list", name+" renamed")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if fmt.Sprintf("%d", result.GetInt("parent_id")) != todolistID {
+			t.Errorf("expected parent_id %s, got %d", todolistID, result.GetInt("parent_id"))
+		}
+
+		h.Run("todo-trash", h.ProjectID, todoID)
+	})
+
+	t.Run("move todo to unknown list", func(t *testing.T) {
+		result := h.Run("todo-move", h.ProjectID, "1", "--to-list", "no such list anywhere")
+
+		if result.Success() {
+			t.Error("expected failure for unknown list")
+		}
+	})
+
+	t.Run("update requires a field", func(t *testing.T) {
+		result := h.Run("todolist-update", h.ProjectID, h.TodolistID)
+
+		if result.Success() {
+			t.Error("expected failure without --name or --description")
+		}
+	})
+
+	t.Run("create requires name", func(t *testing.T) {
+		result := h.Run("todolist-create", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without --name")
+		}
+	})
+
+	t.Run("move requires to-list", func(t *testing.T) {
+		result := h.Run("todo-move", h.ProjectID, "1")
+
+		if result.Success() {
+			t.Error("expected failure without --to-list")
+		}
+	})
+
+	if todolistID != "" {
+		h.Run("trash", h.ProjectID, todolistID)
+	}
+}
+
+func TestTodosImport(t *testing.T) {
+	h := harness.New(t)
+
+	suffix := time.Now().UnixNano()
+	plan := fmt.Sprintf(`- [ ] Import kickoff %d
+  Agenda in the shared doc.
+
+## Import group %d
+- [x] Import done item %d
+- [ ] Import open item %d due:2030-01-01
+`, suffix, suffix, suffix, suffix)
+
+	file := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(file, []byte(plan), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		result := h.Run("todos-import", h.ProjectID, h.TodolistID, "--file", file, "--dry-run")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		if len(created) != 3 {
+			t.Errorf("expected 3 todos in dry run, got %d", len(created))
+		}
+		groups, _ := result.JSON["groups_created"].([]any)
+		if len(groups) != 1 {
+			t.Errorf("expected 1 group in dry run, got %d", len(groups))
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		result := h.Run("todos-import", h.ProjectID, h.TodolistID, "--file", file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		if len(created) != 3 {
+			t.Fatalf("expected 3 todos created, got %d", len(created))
+		}
+		for _, c := range created {
+			item, _ := c.(map[string]any)
+			if id, _ := item["id"].(float64); id == 0 {
+				t.Errorf("expected id for created todo: %v", item)
+			}
+		}
+	})
+
+	t.Run("resume skips existing", func(t *testing.T) {
+		result := h.Run("todos-import", h.ProjectID, h.TodolistID, "--file", file, "--resume")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		created, _ := result.JSON["created"].([]any)
+		skipped, _ := result.JSON["skipped"].([]any)
+		if len(created) != 0 || len(skipped) != 3 {
+			t.Errorf("expected 0 created and 3 skipped, got %d and %d", len(created), len(skipped))
+		}
+	})
+
+	t.Run("missing file flag", func(t *testing.T) {
+		result := h.Run("todos-import", h.ProjectID, h.TodolistID)
+
+		if result.Success() {
+			t.Error("expected failure without --file")
+		}
+	})
+}
+
+func TestTodosExport(t *testing.T) {
+	h := harness.New(t)
+
+	t.Run("markdown to stdout", func(t *testing.T) {
+		result := h.Run("todos-export", h.ProjectID, h.TodolistID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(result.Stdout), "\n") {
+			if line != "" && !strings.HasPrefix(line, "- [") && !strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "## ") {
+				t.Errorf("unexpected markdown line: %q", line)
+			}
+		}
+	})
+
+	t.Run("ics to file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "todos.ics")
+		result := h.Run("todos-export", h.ProjectID, "--all", "--format", "ics", "--out", file)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		if result.GetString("status") != "ok" {
+			t.Error("expected status ok")
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), "BEGIN:VCALENDAR") {
+			t.Errorf("expected iCalendar output, got: %.100s", data)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		result := h.Run("todos-export", h.ProjectID, h.TodolistID, "--format", "xml")
+
+		if result.Success() {
+			t.Error("expected failure for unknown format")
+		}
+	})
+
+	t.Run("missing todolist", func(t *testing.T) {
+		result := h.Run("todos-export", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without todolist or --all")
+		}
+	})
+}
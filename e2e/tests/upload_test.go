@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rzolkos/basecamp-cli/e2e/harness"
 )
@@ -81,11 +83,169 @@ func TestUploads(t *testing.T) {
 		}
 	})
 
-	t.Run("missing vault_id", func(t *testing.T) {
+	t.Run("defaults to top-level vault", func(t *testing.T) {
 		result := h.Run("uploads", h.ProjectID)
 
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		if result.GetInt("vault_id") != vaultID {
+			t.Errorf("expected vault_id %d, got %d", vaultID, result.GetInt("vault_id"))
+		}
+	})
+
+	t.Run("unknown folder path", func(t *testing.T) {
+		result := h.Run("uploads", h.ProjectID, "No Such Folder/Anywhere")
+
+		if result.Success() {
+			t.Error("expected failure with unknown folder")
+		}
+	})
+}
+
+func TestVaults(t *testing.T) {
+	h := harness.New(t)
+
+	parentName := fmt.Sprintf("E2E Folder %d", time.Now().UnixNano())
+
+	t.Run("list folders", func(t *testing.T) {
+		result := h.Run("vaults", h.ProjectID)
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		if _, ok := result.JSON["vaults"]; !ok {
+			t.Error("expected vaults array in response")
+		}
+	})
+
+	t.Run("create nested folders by path", func(t *testing.T) {
+		result := h.Run("vault-create", h.ProjectID, parentName)
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		parentID := result.GetInt("id")
+
+		result = h.Run("vault-create", h.ProjectID, parentName+"/Logos")
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		if result.GetInt("parent_id") != parentID {
+			t.Errorf("expected parent_id %d, got %d", parentID, result.GetInt("parent_id"))
+		}
+
+		result = h.Run("vaults", h.ProjectID, "--parent", parentName)
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		vaults, _ := result.JSON["vaults"].([]any)
+		if len(vaults) != 1 {
+			t.Fatalf("expected 1 subfolder, got %d", len(vaults))
+		}
+		if title, _ := vaults[0].(map[string]any)["title"].(string); title != "Logos" {
+			t.Errorf("expected subfolder Logos, got %q", title)
+		}
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		result := h.Run("vault-create", h.ProjectID)
+
+		if result.Success() {
+			t.Error("expected failure without name")
+		}
+	})
+
+	t.Run("path and parent together", func(t *testing.T) {
+		result := h.Run("vault-create", h.ProjectID, parentName+"/Icons", "--parent", parentName)
+
+		if result.Success() {
+			t.Error("expected failure with both a path and --parent")
+		}
+	})
+}
+
+func TestUploadCreate(t *testing.T) {
+	h := harness.New(t)
+
+	folderName := fmt.Sprintf("E2E Uploads %d", time.Now().UnixNano())
+	if result := h.Run("vault-create", h.ProjectID, folderName); !result.Success() {
+		t.Fatalf("failed to create folder: %s", result.Stderr)
+	}
+
+	testFile := filepath.Join(t.TempDir(), "e2e-upload.txt")
+	if err := os.WriteFile(testFile, []byte("Stored by the e2e tests"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	var uploadID int
+
+	t.Run("store file in folder", func(t *testing.T) {
+		result := h.Run("upload-create", h.ProjectID, folderName, testFile, "--description", "From **e2e**", "--markdown")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		uploadID = result.GetInt("id")
+		if uploadID == 0 {
+			t.Fatal("expected upload id in response")
+		}
+
+		list := h.Run("uploads", h.ProjectID, folderName)
+		if !list.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", list.ExitCode, list.Stderr)
+		}
+		uploads, _ := list.JSON["uploads"].([]any)
+		if len(uploads) != 1 {
+			t.Errorf("expected 1 upload in folder, got %d", len(uploads))
+		}
+	})
+
+	t.Run("update upload", func(t *testing.T) {
+		if uploadID == 0 {
+			t.Skip("no upload created")
+		}
+		result := h.Run("upload-update", h.ProjectID, fmt.Sprintf("%d", uploadID), "--name", "renamed", "--description", "Updated")
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+
+		view := h.Run("upload-view", h.ProjectID, fmt.Sprintf("%d", uploadID))
+		if !strings.HasPrefix(view.GetString("title"), "renamed") {
+			t.Errorf("expected title to start with renamed, got %q", view.GetString("title"))
+		}
+		if view.GetString("description") != "Updated" {
+			t.Errorf("expected description Updated, got %q", view.GetString("description"))
+		}
+	})
+
+	t.Run("update needs a change", func(t *testing.T) {
+		result := h.Run("upload-update", h.ProjectID, "1")
+
+		if result.Success() {
+			t.Error("expected failure without --name or --description")
+		}
+	})
+
+	t.Run("trash upload", func(t *testing.T) {
+		if uploadID == 0 {
+			t.Skip("no upload created")
+		}
+		result := h.Run("upload-trash", h.ProjectID, fmt.Sprintf("%d", uploadID))
+
+		if !result.Success() {
+			t.Fatalf("expected success, got exit code %d\nstderr: %s", result.ExitCode, result.Stderr)
+		}
+		if result.GetString("status") != "ok" {
+			t.Error("expected status ok")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		result := h.Run("upload-create", h.ProjectID, folderName)
+
 		if result.Success() {
-			t.Error("expected failure without vault_id")
+			t.Error("expected failure without file")
 		}
 	})
 }
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,12 +19,34 @@ import (
 const (
 	UserAgent = "Basecamp CLI (https://github.com/rzolkos/basecamp-cli)"
 	Timeout   = 30 * time.Second
+
+	// MaxRetries is how many times a request that failed with a transient
+	// error is repeated
+	MaxRetries = 3
+	RetryDelay = time.Second
 )
 
 type Client struct {
-	token   string
-	baseURL string
-	http    *http.Client
+	token      string
+	baseURL    string
+	http       *http.Client
+	retryDelay time.Duration
+}
+
+// APIError is returned for responses with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d %s\n%s", e.StatusCode, e.Status, e.Body)
+}
+
+// Temporary reports whether the request may succeed if tried again later
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
 }
 
 func New() (*Client, error) {
@@ -37,15 +61,47 @@ func New() (*Client, error) {
 	}
 
 	return &Client{
-		token:   token,
-		baseURL: cfg.APIBaseURL(),
-		http:    &http.Client{Timeout: Timeout},
+		token:      token,
+		baseURL:    cfg.APIBaseURL(),
+		http:       &http.Client{Timeout: Timeout},
+		retryDelay: RetryDelay,
 	}, nil
 }
 
+// NewUnauthenticated returns a client that sends no OAuth token, for
+// chatbot URLs that carry their own key
+func NewUnauthenticated() *Client {
+	return &Client{
+		http:       &http.Client{Timeout: Timeout},
+		retryDelay: RetryDelay,
+	}
+}
+
+// NewWithBaseURL returns a client for the API at baseURL, such as a local
+// test server
+func NewWithBaseURL(baseURL, token string) *Client {
+	return &Client{
+		token:      token,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		http:       &http.Client{Timeout: Timeout},
+		retryDelay: RetryDelay,
+	}
+}
+
 func (c *Client) Get(path string) (json.RawMessage, error) {
+	return c.GetContext(context.Background(), path)
+}
+
+// GetContext is Get with a context that can cancel the request
+func (c *Client) GetContext(ctx context.Context, path string) (json.RawMessage, error) {
 	url := c.resolveURL(path)
-	return c.request(context.Background(), http.MethodGet, url, nil)
+	return c.request(ctx, http.MethodGet, url, nil)
+}
+
+// GetPage fetches one page of a paginated endpoint and returns the URL of
+// the next page, or "" on the last page
+func (c *Client) GetPage(ctx context.Context, path string) (json.RawMessage, string, error) {
+	return c.requestWithPagination(ctx, c.resolveURL(path))
 }
 
 func (c *Client) Post(path string, data any) (json.RawMessage, error) {
@@ -63,32 +119,92 @@ func (c *Client) Delete(path string) (json.RawMessage, error) {
 	return c.request(context.Background(), http.MethodDelete, url, nil)
 }
 
-// UploadFile uploads raw binary data to the given path
-func (c *Client) UploadFile(path string, data []byte, contentType string, size int64) (json.RawMessage, error) {
-	url := c.resolveURL(path)
-	return c.uploadRequest(context.Background(), url, data, contentType, size)
-}
+// UploadProgress is called as an upload's body is sent
+type UploadProgress func(sent, total int64)
 
-func (c *Client) uploadRequest(ctx context.Context, url string, data []byte, contentType string, size int64) (json.RawMessage, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
-	if err != nil {
-		return nil, err
+// UploadFile streams size bytes of body to the given path. Large files
+// take longer than Timeout to send, so instead of a deadline the upload
+// is only canceled when no data moves for that long. A body that is an
+// io.Seeker is sent again from the start if the upload is rate limited.
+func (c *Client) UploadFile(path string, body io.Reader, contentType string, size int64, progress UploadProgress) (json.RawMessage, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stall := c.http.Timeout
+	if stall <= 0 {
+		stall = Timeout
 	}
+	stalled := time.AfterFunc(stall, cancel)
+	defer stalled.Stop()
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("User-Agent", UserAgent)
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", size))
+	// The stall timer replaces the client's deadline
+	uploader := *c.http
+	uploader.Timeout = 0
 
-	resp, err := c.http.Do(req)
+	url := c.resolveURL(path)
+	attempts := 0
+	// Waiting to retry is not a stall; newRequest starts the timer again
+	resp, err := c.doWith(ctx, &uploader, http.MethodPost, func() { stalled.Stop() }, func() (*http.Request, error) {
+		if attempts > 0 {
+			seeker, ok := body.(io.Seeker)
+			if !ok {
+				return nil, errors.New("upload cannot be retried")
+			}
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+		attempts++
+		stalled.Reset(stall)
+
+		var reader io.Reader = http.NoBody
+		if size > 0 {
+			reader = &uploadReader{r: body, total: size, progress: progress, stalled: stalled, stall: stall}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reader)
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = size
+		c.setHeaders(req, false)
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("upload stalled: no progress for %s", stall)
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	stalled.Stop()
 	return c.handleResponse(resp)
 }
 
+// uploadReader reports progress and holds off the stall timer while an
+// upload's body is read
+type uploadReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgress
+	stalled  *time.Timer
+	stall    time.Duration
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	if n > 0 {
+		u.stalled.Reset(u.stall)
+		u.sent += int64(n)
+		if u.progress != nil {
+			u.progress(u.sent, u.total)
+		}
+	}
+	return n, err
+}
+
 // GetAll fetches all pages of a paginated endpoint and returns combined results
 func (c *Client) GetAll(path string) ([]json.RawMessage, error) {
 	var results []json.RawMessage
@@ -119,23 +235,26 @@ func (c *Client) resolveURL(path string) string {
 }
 
 func (c *Client) request(ctx context.Context, method, url string, data any) (json.RawMessage, error) {
-	var body io.Reader
+	var jsonData []byte
 	if data != nil {
-		jsonData, err := json.Marshal(data)
-		if err != nil {
+		var err error
+		if jsonData, err = json.Marshal(data); err != nil {
 			return nil, err
 		}
-		body = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
-	if err != nil {
-		return nil, err
-	}
-
-	c.setHeaders(req, data != nil)
-
-	resp, err := c.http.Do(req)
+	resp, err := c.do(ctx, method, func() (*http.Request, error) {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(jsonData)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		c.setHeaders(req, data != nil)
+		return req, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -145,14 +264,14 @@ func (c *Client) request(ctx context.Context, method, url string, data any) (jso
 }
 
 func (c *Client) requestWithPagination(ctx context.Context, url string) (json.RawMessage, string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, "", err
-	}
-
-	c.setHeaders(req, false)
-
-	resp, err := c.http.Do(req)
+	resp, err := c.do(ctx, http.MethodGet, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setHeaders(req, false)
+		return req, nil
+	})
 	if err != nil {
 		return nil, "", err
 	}
@@ -167,8 +286,79 @@ func (c *Client) requestWithPagination(ctx context.Context, url string) (json.Ra
 	return data, nextURL, nil
 }
 
+// do sends the request built by newRequest, repeating it with a growing
+// delay while it fails with a transient error. Rate limited requests honor
+// Retry-After. POSTs are only repeated when the server did not process
+// them, so nothing is created twice.
+func (c *Client) do(ctx context.Context, method string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	return c.doWith(ctx, c.http, method, nil, newRequest)
+}
+
+// doWith is do with a given HTTP client. beforeWait, if set, is called
+// before each delay between attempts.
+func (c *Client) doWith(ctx context.Context, hc *http.Client, method string, beforeWait func(), newRequest func() (*http.Request, error)) (*http.Response, error) {
+	delay := c.retryDelay
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := hc.Do(req)
+		if attempt == MaxRetries || ctx.Err() != nil || !retryable(method, resp, err) {
+			return resp, err
+		}
+
+		wait := delay
+		if resp != nil {
+			if after := retryAfter(resp.Header.Get("Retry-After")); after > 0 {
+				wait = after
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if beforeWait != nil {
+			beforeWait()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+func retryable(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		// The request may have reached the server
+		return method != http.MethodPost
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
 func (c *Client) setHeaders(req *http.Request, hasBody bool) {
-	req.Header.Set("Authorization", "Bearer "+c.token)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
 	req.Header.Set("User-Agent", UserAgent)
 	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
@@ -182,7 +372,7 @@ func (c *Client) handleResponse(resp *http.Response) (json.RawMessage, error) {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error: %d %s\n%s", resp.StatusCode, resp.Status, string(body))
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
 	}
 
 	if len(body) == 0 {
@@ -1,7 +1,16 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestParseNextLink(t *testing.T) {
@@ -83,3 +92,298 @@ func TestResolveURL(t *testing.T) {
 		})
 	}
 }
+
+func newTestClient(url string) *Client {
+	return &Client{
+		token:      "test",
+		baseURL:    url,
+		http:       &http.Client{Timeout: time.Second},
+		retryDelay: time.Millisecond,
+	}
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	data, err := newTestClient(srv.URL).Get("/test.json")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if string(data) != `{"ok":true}` {
+		t.Errorf("Get() = %s", data)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 requests, got %d", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv.URL).Get("/test.json")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || !apiErr.Temporary() {
+		t.Fatalf("expected a temporary APIError, got %v", err)
+	}
+	if calls != MaxRetries+1 {
+		t.Errorf("expected %d requests, got %d", MaxRetries+1, calls)
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		post   bool
+	}{
+		{"not found", http.StatusNotFound, false},
+		{"post on server error", http.StatusServiceUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			c := newTestClient(srv.URL)
+			var err error
+			if tt.post {
+				_, err = c.Post("/test.json", map[string]string{"a": "b"})
+			} else {
+				_, err = c.Get("/test.json")
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if calls != 1 {
+				t.Errorf("expected 1 request, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestRetryRateLimitedPost(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	if _, err := newTestClient(srv.URL).Post("/test.json", map[string]string{"a": "b"}); err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestRetryStopsWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	c.retryDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.GetContext(ctx, "/test.json"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("retry did not stop when the context was cancelled")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":      0,
+		"5":     5 * time.Second,
+		" 2 ":   2 * time.Second,
+		"-1":    0,
+		"later": 0,
+	}
+	for header, want := range tests {
+		if got := retryAfter(header); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestUnauthenticatedClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	if _, err := NewUnauthenticated().Post(srv.URL+"/lines.json", map[string]string{"content": "hi"}); err != nil {
+		t.Fatalf("Post() error: %v", err)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	body := bytes.Repeat([]byte("x"), 100000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != int64(len(body)) {
+			t.Errorf("Content-Length = %d, want %d", r.ContentLength, len(body))
+		}
+		if len(r.TransferEncoding) > 0 {
+			t.Errorf("unexpected Transfer-Encoding %v", r.TransferEncoding)
+		}
+		if got := r.URL.Query().Get("name"); got != "my report & notes.pdf" {
+			t.Errorf("name = %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		data, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(data, body) {
+			t.Errorf("received %d bytes, want %d", len(data), len(body))
+		}
+		w.Write([]byte(`{"attachable_sgid":"sgid"}`))
+	}))
+	defer srv.Close()
+
+	var sent, total int64
+	progress := func(s, t int64) { sent, total = s, t }
+
+	path := "/attachments.json?name=my+report+%26+notes.pdf"
+	data, err := newTestClient(srv.URL).UploadFile(path, bytes.NewReader(body), "application/pdf", int64(len(body)), progress)
+	if err != nil {
+		t.Fatalf("UploadFile() error: %v", err)
+	}
+	if string(data) != `{"attachable_sgid":"sgid"}` {
+		t.Errorf("UploadFile() = %s", data)
+	}
+	if sent != int64(len(body)) || total != int64(len(body)) {
+		t.Errorf("progress = %d of %d, want %d", sent, total, len(body))
+	}
+}
+
+// slowReader sends its data one chunk at a time with a pause before each
+type slowReader struct {
+	chunks [][]byte
+	pause  time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(r.pause)
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+	return n, nil
+}
+
+func TestUploadFileOutlastsTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	c.http.Timeout = 200 * time.Millisecond
+
+	// Slower overall than the timeout, but never stalled for that long
+	body := &slowReader{chunks: [][]byte{[]byte("aaaa"), []byte("bbbb"), []byte("cccc"), []byte("dddd")}, pause: 80 * time.Millisecond}
+	if _, err := c.UploadFile("/attachments.json", body, "text/plain", 16, nil); err != nil {
+		t.Fatalf("UploadFile() error: %v", err)
+	}
+}
+
+func TestUploadFileStalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	c.http.Timeout = 100 * time.Millisecond
+
+	body := &slowReader{chunks: [][]byte{[]byte("aaaa"), []byte("bbbb")}, pause: 300 * time.Millisecond}
+	_, err := c.UploadFile("/attachments.json", body, "text/plain", 8, nil)
+	if err == nil || !strings.Contains(err.Error(), "stalled") {
+		t.Fatalf("UploadFile() error = %v, want stalled upload", err)
+	}
+}
+
+func TestUploadFileRetriesSeekableBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if string(data) != "hello" {
+			t.Errorf("attempt %d received %q", atomic.LoadInt32(&calls)+1, data)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	if _, err := newTestClient(srv.URL).UploadFile("/attachments.json", strings.NewReader("hello"), "text/plain", 5, nil); err != nil {
+		t.Fatalf("UploadFile() error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestUploadFileWaitsOutRateLimit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// Retry-After is longer than the stall timeout, which must not count
+	// the wait as a stalled upload
+	c := newTestClient(srv.URL)
+	c.http.Timeout = 200 * time.Millisecond
+
+	if _, err := c.UploadFile("/attachments.json", strings.NewReader("hello"), "text/plain", 5, nil); err != nil {
+		t.Fatalf("UploadFile() error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
@@ -0,0 +1,402 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+// assignmentWorkers bounds how many projects are fetched at once
+const assignmentWorkers = 4
+
+type MyTodosCmd struct{}
+
+type AssignedCmd struct{}
+
+// Assignment is a todo or card step assigned to a person
+type Assignment struct {
+	Type      string `json:"type"`
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+	DueOn     string `json:"due_on,omitempty"`
+	ParentID  int    `json:"parent_id"`
+	Parent    string `json:"parent"`
+}
+
+type AssignmentDueGroup struct {
+	DueOn string       `json:"due_on"`
+	Items []Assignment `json:"items"`
+}
+
+type ProjectAssignments struct {
+	ProjectID   int                  `json:"project_id"`
+	ProjectName string               `json:"project_name"`
+	Count       int                  `json:"count"`
+	Due         []AssignmentDueGroup `json:"due"`
+}
+
+type AssignmentsOutput struct {
+	PersonID   int                  `json:"person_id"`
+	PersonName string               `json:"person_name"`
+	Total      int                  `json:"total"`
+	Projects   []ProjectAssignments `json:"projects"`
+	Errors     []string             `json:"errors,omitempty"`
+}
+
+// assignmentFilter holds the optional filters for the assignments view
+type assignmentFilter struct {
+	Overdue          bool
+	DueBy            string
+	IncludeCompleted bool
+	Today            string
+}
+
+func (f assignmentFilter) matches(a Assignment) bool {
+	if a.Completed && !f.IncludeCompleted {
+		return false
+	}
+	if f.Overdue && (a.Completed || a.DueOn == "" || a.DueOn >= f.Today) {
+		return false
+	}
+	if f.DueBy != "" && (a.DueOn == "" || a.DueOn > f.DueBy) {
+		return false
+	}
+	return true
+}
+
+// parseDayCount parses a day count like "7", "7d" or "2w"
+func parseDayCount(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(s, "w"):
+		multiplier = 7
+		s = strings.TrimSuffix(s, "w")
+	case strings.HasSuffix(s, "d"):
+		s = strings.TrimSuffix(s, "d")
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid day count '%s' (use e.g. 7d or 2w)", s)
+	}
+	return n * multiplier, nil
+}
+
+// groupByDueDate groups assignments by due date, earliest first, with
+// undated assignments last
+func groupByDueDate(items []Assignment) []AssignmentDueGroup {
+	sorted := make([]Assignment, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].DueOn, sorted[j].DueOn
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		return a < b
+	})
+
+	var groups []AssignmentDueGroup
+	for _, item := range sorted {
+		if n := len(groups); n > 0 && groups[n-1].DueOn == item.DueOn {
+			groups[n-1].Items = append(groups[n-1].Items, item)
+			continue
+		}
+		groups = append(groups, AssignmentDueGroup{DueOn: item.DueOn, Items: []Assignment{item}})
+	}
+	return groups
+}
+
+// fetchProjectAssignments collects the todos and card steps in a project
+// that are assigned to personID
+func fetchProjectAssignments(cl *client.Client, project ProjectDetail, personID int, includeCompleted bool) ([]Assignment, error) {
+	var items []Assignment
+
+	for _, dock := range project.Dock {
+		var found []Assignment
+		var err error
+		switch dock.Name {
+		case "todoset":
+			found, err = fetchTodosetAssignments(cl, dock.URL, personID, includeCompleted)
+		case "kanban_board":
+			found, err = fetchBoardAssignments(cl, dock.URL, personID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, found...)
+	}
+
+	return items, nil
+}
+
+func fetchTodosetAssignments(cl *client.Client, todosetURL string, personID int, includeCompleted bool) ([]Assignment, error) {
+	data, err := cl.Get(todosetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var todoset TodoSet
+	if err := json.Unmarshal(data, &todoset); err != nil {
+		return nil, err
+	}
+
+	listsData, err := cl.GetAll(todoset.TodolistsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Assignment
+	for _, tlJSON := range listsData {
+		var todolist Todolist
+		if err := json.Unmarshal(tlJSON, &todolist); err != nil {
+			return nil, err
+		}
+
+		// Todos in groups are not included in the list's own todos
+		todosURLs := []string{todolist.TodosURL}
+		if todolist.GroupsURL != "" {
+			groupsData, err := cl.GetAll(todolist.GroupsURL)
+			if err != nil {
+				return nil, err
+			}
+			for _, groupJSON := range groupsData {
+				var group TodolistGroup
+				if err := json.Unmarshal(groupJSON, &group); err != nil {
+					return nil, err
+				}
+				todosURLs = append(todosURLs, group.TodosURL)
+			}
+		}
+
+		for _, todosURL := range todosURLs {
+			todos, err := fetchTodos(cl, todosURL, includeCompleted)
+			if err != nil {
+				return nil, err
+			}
+			for _, todo := range todos {
+				if !hasAssigneeID(todo.Assignees, personID) {
+					continue
+				}
+				items = append(items, Assignment{
+					Type:      "todo",
+					ID:        todo.ID,
+					Title:     todo.Content,
+					Completed: todo.Completed,
+					DueOn:     todo.DueOn,
+					ParentID:  todolist.ID,
+					Parent:    todolist.Title,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func fetchBoardAssignments(cl *client.Client, boardURL string, personID int) ([]Assignment, error) {
+	data, err := cl.Get(boardURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var cardTable CardTableDetail
+	if err := json.Unmarshal(data, &cardTable); err != nil {
+		return nil, err
+	}
+
+	var items []Assignment
+	for _, list := range cardTable.Lists {
+		if list.CardsCount == 0 {
+			continue
+		}
+
+		cardsData, err := cl.GetAll(list.CardsURL)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cardJSON := range cardsData {
+			var card CardSummary
+			if err := json.Unmarshal(cardJSON, &card); err != nil {
+				return nil, err
+			}
+			for _, step := range card.Steps {
+				if !hasAssigneeID(step.Assignees, personID) {
+					continue
+				}
+				items = append(items, Assignment{
+					Type:      "step",
+					ID:        step.ID,
+					Title:     step.Title,
+					Completed: step.Completed,
+					DueOn:     step.DueOn,
+					ParentID:  card.ID,
+					Parent:    card.Title,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func hasAssigneeID(assignees []Assignee, personID int) bool {
+	for _, a := range assignees {
+		if a.ID == personID {
+			return true
+		}
+	}
+	return false
+}
+
+// collectAssignments fetches assignments across all projects concurrently
+// and returns them grouped by project and due date
+func collectAssignments(cl *client.Client, person Person, filter assignmentFilter) (AssignmentsOutput, error) {
+	projectsData, err := cl.GetAll("/projects.json")
+	if err != nil {
+		return AssignmentsOutput{}, err
+	}
+
+	projects := make([]ProjectDetail, len(projectsData))
+	for i, projectJSON := range projectsData {
+		if err := json.Unmarshal(projectJSON, &projects[i]); err != nil {
+			return AssignmentsOutput{}, err
+		}
+	}
+
+	results := make([][]Assignment, len(projects))
+	errs := make([]error, len(projects))
+	forEachConcurrently(len(projects), assignmentWorkers, func(i int) {
+		results[i], errs[i] = fetchProjectAssignments(cl, projects[i], person.ID, filter.IncludeCompleted)
+	})
+
+	output := AssignmentsOutput{
+		PersonID:   person.ID,
+		PersonName: person.Name,
+		Projects:   []ProjectAssignments{},
+	}
+
+	for i, project := range projects {
+		if errs[i] != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", project.Name, errs[i]))
+			continue
+		}
+
+		var matched []Assignment
+		for _, item := range results[i] {
+			if filter.matches(item) {
+				matched = append(matched, item)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		output.Projects = append(output.Projects, ProjectAssignments{
+			ProjectID:   project.ID,
+			ProjectName: project.Name,
+			Count:       len(matched),
+			Due:         groupByDueDate(matched),
+		})
+		output.Total += len(matched)
+	}
+
+	return output, nil
+}
+
+// parseAssignmentFlags parses the filter flags shared by my-todos and assigned
+func parseAssignmentFlags(args []string) (assignmentFilter, string, error) {
+	now := time.Now()
+	filter := assignmentFilter{Today: now.Format("2006-01-02")}
+	var person string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--overdue":
+			filter.Overdue = true
+		case "--include-completed":
+			filter.IncludeCompleted = true
+		case "--due-within":
+			if i+1 < len(args) {
+				days, err := parseDayCount(args[i+1])
+				if err != nil {
+					return filter, "", err
+				}
+				filter.DueBy = now.AddDate(0, 0, days).Format("2006-01-02")
+				i++
+			}
+		case "--person":
+			if i+1 < len(args) {
+				person = args[i+1]
+				i++
+			}
+		}
+	}
+
+	return filter, person, nil
+}
+
+func (c *MyTodosCmd) Run(args []string) error {
+	filter, _, err := parseAssignmentFlags(args)
+	if err != nil {
+		return err
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	me, err := fetchMyProfile(cl)
+	if err != nil {
+		return err
+	}
+
+	output, err := collectAssignments(cl, me, filter)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(output)
+}
+
+func (c *AssignedCmd) Run(args []string) error {
+	filter, personRef, err := parseAssignmentFlags(args)
+	if err != nil {
+		return err
+	}
+
+	if personRef == "" {
+		return errors.New("usage: basecamp assigned --person <id|name|email> [--overdue] [--due-within <7d>] [--include-completed]")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	people, err := fetchPeople(cl)
+	if err != nil {
+		return err
+	}
+
+	person, err := resolvePerson(people, personRef)
+	if err != nil {
+		return err
+	}
+
+	output, err := collectAssignments(cl, person, filter)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(output)
+}
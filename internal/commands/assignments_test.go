@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseDayCount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "7", want: 7},
+		{in: "7d", want: 7},
+		{in: "2w", want: 14},
+		{in: " 3D ", want: 3},
+		{in: "0d", want: 0},
+		{in: "-1d", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDayCount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDayCount(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDayCount(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDayCount(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAssignmentFilter(t *testing.T) {
+	overdue := Assignment{ID: 1, DueOn: "2026-01-05"}
+	soon := Assignment{ID: 2, DueOn: "2026-01-12"}
+	later := Assignment{ID: 3, DueOn: "2026-03-01"}
+	undated := Assignment{ID: 4}
+	done := Assignment{ID: 5, DueOn: "2026-01-01", Completed: true}
+	all := []Assignment{overdue, soon, later, undated, done}
+
+	tests := []struct {
+		name   string
+		filter assignmentFilter
+		want   []int
+	}{
+		{name: "default hides completed", filter: assignmentFilter{}, want: []int{1, 2, 3, 4}},
+		{name: "include completed", filter: assignmentFilter{IncludeCompleted: true}, want: []int{1, 2, 3, 4, 5}},
+		{name: "overdue", filter: assignmentFilter{Overdue: true, Today: "2026-01-10"}, want: []int{1}},
+		{name: "overdue ignores completed", filter: assignmentFilter{Overdue: true, IncludeCompleted: true, Today: "2026-01-10"}, want: []int{1}},
+		{name: "due within", filter: assignmentFilter{DueBy: "2026-01-17"}, want: []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, a := range all {
+				if tt.filter.matches(a) {
+					got = append(got, a.ID)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupByDueDate(t *testing.T) {
+	items := []Assignment{
+		{ID: 1},
+		{ID: 2, DueOn: "2026-02-01"},
+		{ID: 3, DueOn: "2026-01-15"},
+		{ID: 4, DueOn: "2026-02-01"},
+	}
+
+	groups := groupByDueDate(items)
+
+	wantDates := []string{"2026-01-15", "2026-02-01", ""}
+	if len(groups) != len(wantDates) {
+		t.Fatalf("expected %d groups, got %d", len(wantDates), len(groups))
+	}
+	for i, want := range wantDates {
+		if groups[i].DueOn != want {
+			t.Errorf("group %d due_on = %q, want %q", i, groups[i].DueOn, want)
+		}
+	}
+	if len(groups[1].Items) != 2 || groups[1].Items[0].ID != 2 || groups[1].Items[1].ID != 4 {
+		t.Errorf("expected items 2 and 4 in order for 2026-02-01, got %+v", groups[1].Items)
+	}
+	if items[0].ID != 1 {
+		t.Error("groupByDueDate should not reorder its input")
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	var calls, running, maxRunning int32
+	seen := make([]bool, 20)
+
+	forEachConcurrently(len(seen), 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		seen[i] = true
+		atomic.AddInt32(&calls, 1)
+		atomic.AddInt32(&running, -1)
+	})
+
+	if calls != 20 {
+		t.Errorf("expected 20 calls, got %d", calls)
+	}
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("index %d was not visited", i)
+		}
+	}
+
+	forEachConcurrently(0, 3, func(int) { t.Error("fn called with n = 0") })
+}
@@ -1,9 +1,17 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -45,13 +53,25 @@ type CampfireLine struct {
 	ID        int     `json:"id"`
 	Content   string  `json:"content"`
 	CreatedAt string  `json:"created_at"`
+	AppURL    string  `json:"app_url"`
 	Creator   Creator `json:"creator"`
+
+	Attachments []CampfireAttachment `json:"attachments"`
+}
+
+// CampfireAttachment is a file posted in a campfire line
+type CampfireAttachment struct {
+	Title       string `json:"title"`
+	ContentType string `json:"content_type"`
+	ByteSize    int64  `json:"byte_size"`
+	DownloadURL string `json:"download_url"`
 }
 
 type CampfireListOutput struct {
 	ProjectID  int                 `json:"project_id"`
 	CampfireID int                 `json:"campfire_id"`
 	Lines      []CampfireLineBrief `json:"lines"`
+	LastLineID int                 `json:"last_line_id,omitempty"`
 }
 
 type CampfireLineBrief struct {
@@ -61,12 +81,163 @@ type CampfireLineBrief struct {
 	CreatedAt string `json:"created_at"`
 }
 
+func campfireLineBrief(line CampfireLine) CampfireLineBrief {
+	return CampfireLineBrief{
+		ID:        line.ID,
+		Content:   line.Content,
+		Creator:   line.Creator.Name,
+		CreatedAt: line.CreatedAt,
+	}
+}
+
+// campfireCursor marks where a campfire was last read, by line ID or time
+type campfireCursor struct {
+	LineID int
+	Time   time.Time
+}
+
+// parseCampfireCursor parses a --since value: a line ID, or any time
+// parseNaturalTime accepts
+func parseCampfireCursor(s string, now time.Time) (campfireCursor, error) {
+	if id, err := strconv.Atoi(s); err == nil {
+		return campfireCursor{LineID: id}, nil
+	}
+	t, err := parseNaturalTime(s, now)
+	if err != nil {
+		return campfireCursor{}, fmt.Errorf("--since must be a line ID or a time: %w", err)
+	}
+	return campfireCursor{Time: t}, nil
+}
+
+// after reports whether line is newer than the cursor. The zero cursor
+// is before every line.
+func (c campfireCursor) after(line CampfireLine) bool {
+	if c.LineID != 0 || c.Time.IsZero() {
+		return line.ID > c.LineID
+	}
+	created, err := time.Parse(time.RFC3339, line.CreatedAt)
+	return err == nil && created.After(c.Time)
+}
+
+// fetchCampfireLinesSince returns the lines after the cursor, oldest
+// first. Lines come newest first, so paging stops at the first page that
+// reaches the cursor.
+func fetchCampfireLinesSince(ctx context.Context, cl *client.Client, linesURL string, cursor campfireCursor) ([]CampfireLine, error) {
+	var lines []CampfireLine
+
+	for url := linesURL; url != ""; {
+		data, next, err := cl.GetPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []CampfireLine
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, err
+		}
+
+		reached := false
+		for _, line := range page {
+			if cursor.after(line) {
+				lines = append(lines, line)
+			} else {
+				reached = true
+			}
+		}
+		if reached {
+			break
+		}
+		url = next
+	}
+
+	sort.Slice(lines, func(i, j int) bool { return lines[i].ID < lines[j].ID })
+	return lines, nil
+}
+
+const (
+	campfirePollMin = 2 * time.Second
+	campfirePollMax = 30 * time.Second
+)
+
+// nextPollInterval polls quickly while a conversation is active and backs
+// off gradually while it is quiet
+func nextPollInterval(current time.Duration, gotLines bool) time.Duration {
+	if gotLines {
+		return campfirePollMin
+	}
+	next := current * 3 / 2
+	if next > campfirePollMax {
+		return campfirePollMax
+	}
+	return next
+}
+
+// followCampfire prints new lines as NDJSON until ctx is cancelled.
+// Errors the client could not retry away are reported on stderr and
+// polling continues, unless the request itself was rejected.
+func followCampfire(ctx context.Context, cl *client.Client, linesURL string, cursor campfireCursor) error {
+	enc := json.NewEncoder(os.Stdout)
+	interval := campfirePollMin
+
+	for {
+		lines, err := fetchCampfireLinesSince(ctx, cl, linesURL, cursor)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && !apiErr.Temporary() {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "campfire: %v (retrying in %s)\n", err, campfirePollMax)
+			interval = campfirePollMax
+		} else {
+			for _, line := range lines {
+				if err := enc.Encode(campfireLineBrief(line)); err != nil {
+					return err
+				}
+				cursor = campfireCursor{LineID: line.ID}
+			}
+			interval = nextPollInterval(interval, len(lines) > 0)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
 func (c *CampfireCmd) Run(args []string) error {
-	projectID, _, err := getProjectID(args)
+	projectID, remaining, err := getProjectID(args)
 	if err != nil {
 		return err
 	}
 
+	var since string
+	follow := false
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--follow", "-f":
+			follow = true
+		case "--since":
+			if i+1 < len(remaining) {
+				since = remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	var cursor campfireCursor
+	if since != "" {
+		if cursor, err = parseCampfireCursor(since, time.Now()); err != nil {
+			return err
+		}
+	}
+
 	cl, err := client.New()
 	if err != nil {
 		return err
@@ -77,15 +248,44 @@ func (c *CampfireCmd) Run(args []string) error {
 		return err
 	}
 
-	// Get lines
-	linesData, err := cl.Get(campfire.LinesURL)
-	if err != nil {
-		return err
+	if follow {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Without --since, start from the newest line
+		if since == "" {
+			data, _, err := cl.GetPage(ctx, campfire.LinesURL)
+			if err != nil {
+				return err
+			}
+			var latest []CampfireLine
+			if err := json.Unmarshal(data, &latest); err != nil {
+				return err
+			}
+			for _, line := range latest {
+				if line.ID > cursor.LineID {
+					cursor.LineID = line.ID
+				}
+			}
+		}
+
+		return followCampfire(ctx, cl, campfire.LinesURL, cursor)
 	}
 
 	var lines []CampfireLine
-	if err := json.Unmarshal(linesData, &lines); err != nil {
-		return err
+	if since != "" {
+		if lines, err = fetchCampfireLinesSince(context.Background(), cl, campfire.LinesURL, cursor); err != nil {
+			return err
+		}
+	} else {
+		// Get lines
+		linesData, err := cl.Get(campfire.LinesURL)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(linesData, &lines); err != nil {
+			return err
+		}
 	}
 
 	output := CampfireListOutput{
@@ -95,11 +295,9 @@ func (c *CampfireCmd) Run(args []string) error {
 	}
 
 	for i, line := range lines {
-		output.Lines[i] = CampfireLineBrief{
-			ID:        line.ID,
-			Content:   line.Content,
-			Creator:   line.Creator.Name,
-			CreatedAt: line.CreatedAt,
+		output.Lines[i] = campfireLineBrief(line)
+		if line.ID > output.LastLineID {
+			output.LastLineID = line.ID
 		}
 	}
 
@@ -109,10 +307,12 @@ func (c *CampfireCmd) Run(args []string) error {
 type CampfirePostCmd struct{}
 
 type CampfirePostOutput struct {
-	Status  string `json:"status"`
-	ID      int    `json:"id"`
-	Content string `json:"content"`
-	Message string `json:"message"`
+	Status          string   `json:"status"`
+	ID              int      `json:"id"`
+	Content         string   `json:"content"`
+	AttachableSGID  string   `json:"attachable_sgid,omitempty"`
+	AttachableSGIDs []string `json:"attachable_sgids,omitempty"`
+	Message         string   `json:"message"`
 }
 
 func (c *CampfirePostCmd) Run(args []string) error {
@@ -121,20 +321,26 @@ func (c *CampfirePostCmd) Run(args []string) error {
 		return err
 	}
 
-	// Parse --content flag
-	var content string
+	var cf contentFlags
+	var af attachFlags
 
 	for i := 0; i < len(remaining); i++ {
-		if remaining[i] == "--content" {
-			if i+1 < len(remaining) {
-				content = remaining[i+1]
-				i++
-			}
+		if cf.parse(remaining, &i) || af.parse(remaining, &i) {
+			continue
+		}
+		// --file is the original name for --attach
+		if remaining[i] == "--file" && i+1 < len(remaining) {
+			af.Paths = append(af.Paths, remaining[i+1])
+			i++
 		}
 	}
 
-	if content == "" {
-		return errors.New("--content required")
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
+	if content == "" && len(af.Paths) == 0 {
+		return errors.New("--content, --content-file, --stdin or --attach required")
 	}
 
 	cl, err := client.New()
@@ -152,6 +358,32 @@ func (c *CampfirePostCmd) Run(args []string) error {
 		"content": content,
 	}
 
+	// Mentions and attachments need a rich text line, and markdown
+	// already is one
+	richContent := content
+	if !cf.enabled() {
+		richContent = textToHTML(content)
+	}
+	mentions := 0
+	if cf.expandsMentions() && content != "" {
+		if richContent, mentions, err = expandMentions(cl, richContent); err != nil {
+			return err
+		}
+	}
+
+	if content != "" && len(af.Paths) > 0 {
+		richContent += "<br>"
+	}
+	richContent, sgids, err := af.embed(cl, richContent)
+	if err != nil {
+		return err
+	}
+
+	if cf.enabled() || mentions > 0 || len(sgids) > 0 {
+		payload["content"] = richContent
+		payload["content_type"] = "text/html"
+	}
+
 	// POST to lines URL
 	linesURL := campfire.LinesURL
 	// Convert from full URL to path
@@ -169,10 +401,111 @@ func (c *CampfirePostCmd) Run(args []string) error {
 		return err
 	}
 
-	return PrintJSON(CampfirePostOutput{
-		Status:  "ok",
-		ID:      created.ID,
-		Content: created.Content,
-		Message: "Message posted to campfire",
+	output := CampfirePostOutput{
+		Status:          "ok",
+		ID:              created.ID,
+		Content:         created.Content,
+		AttachableSGIDs: sgids,
+		Message:         "Message posted to campfire",
+	}
+	if len(sgids) > 0 {
+		output.AttachableSGID = sgids[0]
+	}
+	return PrintJSON(output)
+}
+
+// CampfireLineCmd shows a single campfire line
+type CampfireLineCmd struct{}
+
+type CampfireLineDetailOutput struct {
+	ID          int                  `json:"id"`
+	Content     string               `json:"content"`
+	Creator     string               `json:"creator"`
+	CreatedAt   string               `json:"created_at"`
+	URL         string               `json:"url"`
+	Attachments []CampfireAttachment `json:"attachments,omitempty"`
+}
+
+// campfireLinePath returns the API path of a line in the project's campfire
+func campfireLinePath(cl *client.Client, projectID, lineID string) (string, error) {
+	_, campfire, err := fetchCampfire(cl, projectID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/buckets/%s/chats/%d/lines/%s.json", projectID, campfire.ID, lineID), nil
+}
+
+func (c *CampfireLineCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp campfire-line [project_id] <line_id>")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	path, err := campfireLinePath(cl, projectID, remaining[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Get(path)
+	if err != nil {
+		return err
+	}
+
+	var line CampfireLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return err
+	}
+
+	return PrintJSON(CampfireLineDetailOutput{
+		ID:          line.ID,
+		Content:     line.Content,
+		Creator:     line.Creator.Name,
+		CreatedAt:   line.CreatedAt,
+		URL:         line.AppURL,
+		Attachments: line.Attachments,
+	})
+}
+
+// CampfireDeleteCmd deletes a campfire line
+type CampfireDeleteCmd struct{}
+
+func (c *CampfireDeleteCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp campfire-delete [project_id] <line_id>")
+	}
+	lineID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	path, err := campfireLinePath(cl, projectID, lineID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cl.Delete(path); err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":  "ok",
+		"line_id": lineID,
+		"message": "Campfire line deleted",
 	})
 }
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCampfireCursor(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	cursor, err := parseCampfireCursor("12345", now)
+	if err != nil || cursor.LineID != 12345 {
+		t.Errorf("parseCampfireCursor(id) = %+v, %v", cursor, err)
+	}
+
+	cursor, err = parseCampfireCursor("-2h", now)
+	if err != nil || !cursor.Time.Equal(now.Add(-2*time.Hour)) {
+		t.Errorf("parseCampfireCursor(-2h) = %+v, %v", cursor, err)
+	}
+
+	if _, err := parseCampfireCursor("whenever", now); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
+
+func TestCampfireCursorAfter(t *testing.T) {
+	line := CampfireLine{ID: 10, CreatedAt: "2026-03-04T12:00:00Z"}
+
+	tests := []struct {
+		name   string
+		cursor campfireCursor
+		want   bool
+	}{
+		{"zero cursor", campfireCursor{}, true},
+		{"older id", campfireCursor{LineID: 9}, true},
+		{"same id", campfireCursor{LineID: 10}, false},
+		{"earlier time", campfireCursor{Time: time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)}, true},
+		{"same time", campfireCursor{Time: time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cursor.after(line); got != tt.want {
+				t.Errorf("after() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextPollInterval(t *testing.T) {
+	if got := nextPollInterval(20*time.Second, true); got != campfirePollMin {
+		t.Errorf("new lines should reset to %v, got %v", campfirePollMin, got)
+	}
+	if got := nextPollInterval(campfirePollMin, false); got != 3*time.Second {
+		t.Errorf("quiet poll should back off to 3s, got %v", got)
+	}
+	if got := nextPollInterval(campfirePollMax, false); got != campfirePollMax {
+		t.Errorf("backoff should stop at %v, got %v", campfirePollMax, got)
+	}
+}
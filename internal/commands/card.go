@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"errors"
+	"html"
 	"regexp"
 	"strings"
 
@@ -35,7 +36,14 @@ type Comment struct {
 	ID        int     `json:"id"`
 	Content   string  `json:"content"`
 	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	AppURL    string  `json:"app_url"`
 	Creator   Creator `json:"creator"`
+	Parent    struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	} `json:"parent"`
 }
 
 type CommentOutput struct {
@@ -65,15 +73,23 @@ func (c *CardCmd) Run(args []string) error {
 	}
 
 	if len(remaining) < 1 {
-		return errors.New("usage: basecamp card [project_id] <card_id> [--comments]")
+		return errors.New("usage: basecamp card [project_id] <card_id> [--comments] [--body markdown|text|html]")
 	}
 	cardID := remaining[0]
 
 	showComments := false
-	for _, arg := range remaining[1:] {
-		if arg == "--comments" {
+	body := bodyMarkdown
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--comments":
 			showComments = true
-			break
+		case "--body":
+			if i+1 < len(remaining) {
+				if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+					return err
+				}
+				i++
+			}
 		}
 	}
 
@@ -100,7 +116,7 @@ func (c *CardCmd) Run(args []string) error {
 		CreatedAt:   card.CreatedAt,
 		UpdatedAt:   card.UpdatedAt,
 		URL:         card.AppURL,
-		Description: stripHTML(coalesce(card.Content, card.Description, "No description")),
+		Description: renderBody(body, coalesce(card.Content, card.Description, "No description")),
 	}
 
 	if len(card.Assignees) > 0 {
@@ -113,23 +129,12 @@ func (c *CardCmd) Run(args []string) error {
 	if len(card.Steps) > 0 {
 		output.Steps = make([]StepOutput, len(card.Steps))
 		for i, s := range card.Steps {
-			var assignees []string
-			for _, a := range s.Assignees {
-				assignees = append(assignees, a.Name)
-			}
-			output.Steps[i] = StepOutput{
-				ID:        s.ID,
-				Title:     s.Title,
-				Completed: s.Completed,
-				DueOn:     s.DueOn,
-				Position:  s.Position,
-				Assignees: assignees,
-			}
+			output.Steps[i] = stepToOutput(s)
 		}
 	}
 
 	if showComments && card.CommentsCount > 0 {
-		comments, err := fetchComments(cl, card.CommentsURL)
+		comments, err := fetchComments(cl, card.CommentsURL, body)
 		if err != nil {
 			return err
 		}
@@ -151,6 +156,28 @@ func stripHTML(html string) string {
 	return strings.TrimSpace(text)
 }
 
+var lineBreakTagRegex = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6]|blockquote|pre)>`)
+var listItemTagRegex = regexp.MustCompile(`(?i)<li[^>]*>`)
+var blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+// htmlToText converts rich text to plain text, keeping line breaks
+func htmlToText(s string) string {
+	if s == "" {
+		return ""
+	}
+	text := lineBreakTagRegex.ReplaceAllString(s, "\n")
+	text = listItemTagRegex.ReplaceAllString(text, "- ")
+	text = htmlTagRegex.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	text = blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
 func coalesce(values ...string) string {
 	for _, v := range values {
 		if v != "" {
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
 
-var errBoardIDRequired = errors.New("usage: basecamp cards [project_id] <board_id> [--column <name>]")
+var errBoardIDRequired = errors.New("usage: basecamp cards [project_id] <board_id> [--column <name>] [--assignee <name|id>] [--due-before <date>] [--overdue] [--mine] [--unassigned] [--sort position|due|updated]")
 
 type CardsCmd struct{}
 
@@ -33,15 +36,30 @@ type Creator struct {
 }
 
 type CardSummary struct {
-	ID      int     `json:"id"`
-	Title   string  `json:"title"`
-	Creator Creator `json:"creator"`
+	ID            int        `json:"id"`
+	Title         string     `json:"title"`
+	Completed     bool       `json:"completed"`
+	DueOn         string     `json:"due_on"`
+	CreatedAt     string     `json:"created_at"`
+	UpdatedAt     string     `json:"updated_at"`
+	CommentsCount int        `json:"comments_count"`
+	AppURL        string     `json:"app_url"`
+	Creator       Creator    `json:"creator"`
+	Assignees     []Assignee `json:"assignees"`
+	Steps         []Step     `json:"steps"`
 }
 
 type CardOutput struct {
-	ID      int    `json:"id"`
-	Title   string `json:"title"`
-	Creator string `json:"creator"`
+	ID             int      `json:"id"`
+	Title          string   `json:"title"`
+	Creator        string   `json:"creator"`
+	Completed      bool     `json:"completed"`
+	DueOn          string   `json:"due_on,omitempty"`
+	Assignees      []string `json:"assignees,omitempty"`
+	StepsCompleted int      `json:"steps_completed"`
+	StepsTotal     int      `json:"steps_total"`
+	CommentsCount  int      `json:"comments_count"`
+	UpdatedAt      string   `json:"updated_at"`
 }
 
 type ColumnCards struct {
@@ -55,6 +73,133 @@ type CardsOutput struct {
 	Columns    []ColumnCards `json:"columns"`
 }
 
+// cardFilter holds the optional filters for the cards listing
+type cardFilter struct {
+	Assignee   string
+	DueBefore  string
+	Overdue    bool
+	Unassigned bool
+	PersonID   int
+	Today      string
+}
+
+// parseDueDate turns a date such as "2026-02-01", "tomorrow" or "+2w" into
+// the YYYY-MM-DD form that due dates are compared in
+func parseDueDate(s string, now time.Time) (string, error) {
+	t, err := parseNaturalTime(s, now)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+func (f cardFilter) active() bool {
+	return f.Assignee != "" || f.DueBefore != "" || f.Overdue || f.Unassigned || f.PersonID != 0
+}
+
+// matches reports whether a card passes every filter that is set.
+// Due dates are ISO 8601 (YYYY-MM-DD) so they compare as strings.
+func (f cardFilter) matches(card CardSummary) bool {
+	if f.Unassigned && len(card.Assignees) > 0 {
+		return false
+	}
+	if f.PersonID != 0 && !hasAssignee(card.Assignees, func(a Assignee) bool { return a.ID == f.PersonID }) {
+		return false
+	}
+	if f.Assignee != "" {
+		id, _ := strconv.Atoi(f.Assignee)
+		want := strings.ToLower(f.Assignee)
+		if !hasAssignee(card.Assignees, func(a Assignee) bool {
+			return a.ID == id || strings.Contains(strings.ToLower(a.Name), want)
+		}) {
+			return false
+		}
+	}
+	if f.DueBefore != "" && (card.DueOn == "" || card.DueOn >= f.DueBefore) {
+		return false
+	}
+	if f.Overdue && (card.Completed || card.DueOn == "" || card.DueOn >= f.Today) {
+		return false
+	}
+	return true
+}
+
+func hasAssignee(assignees []Assignee, match func(Assignee) bool) bool {
+	for _, a := range assignees {
+		if match(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func cardToOutput(card CardSummary) CardOutput {
+	creator := "Unknown"
+	if card.Creator.Name != "" {
+		creator = card.Creator.Name
+	}
+
+	var assignees []string
+	for _, a := range card.Assignees {
+		assignees = append(assignees, a.Name)
+	}
+
+	completedSteps := 0
+	for _, s := range card.Steps {
+		if s.Completed {
+			completedSteps++
+		}
+	}
+
+	return CardOutput{
+		ID:             card.ID,
+		Title:          card.Title,
+		Creator:        creator,
+		Completed:      card.Completed,
+		DueOn:          card.DueOn,
+		Assignees:      assignees,
+		StepsCompleted: completedSteps,
+		StepsTotal:     len(card.Steps),
+		CommentsCount:  card.CommentsCount,
+		UpdatedAt:      card.UpdatedAt,
+	}
+}
+
+// sortCards orders cards within a column. "position" keeps the board order,
+// "due" puts the earliest due date first (undated cards last) and "updated"
+// puts the most recently updated card first.
+func sortCards(cards []CardOutput, by string) {
+	switch by {
+	case "due":
+		sort.SliceStable(cards, func(i, j int) bool {
+			if cards[i].DueOn == "" || cards[j].DueOn == "" {
+				return cards[j].DueOn == "" && cards[i].DueOn != ""
+			}
+			return cards[i].DueOn < cards[j].DueOn
+		})
+	case "updated":
+		sort.SliceStable(cards, func(i, j int) bool {
+			return cards[i].UpdatedAt > cards[j].UpdatedAt
+		})
+	}
+}
+
+// fetchCards gets every page of cards in a column
+func fetchCards(cl *client.Client, cardsURL string) ([]CardSummary, error) {
+	pages, err := cl.GetAll(cardsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]CardSummary, len(pages))
+	for i, cardJSON := range pages {
+		if err := json.Unmarshal(cardJSON, &cards[i]); err != nil {
+			return nil, err
+		}
+	}
+	return cards, nil
+}
+
 func (c *CardsCmd) Run(args []string) error {
 	projectID, remaining, err := getProjectID(args)
 	if err != nil {
@@ -67,18 +212,66 @@ func (c *CardsCmd) Run(args []string) error {
 	boardID := remaining[0]
 
 	var columnFilter string
+	sortBy := "position"
+	mine := false
+	now := time.Now()
+	filter := cardFilter{Today: now.Format("2006-01-02")}
+
 	for i := 1; i < len(remaining); i++ {
-		if remaining[i] == "--column" && i+1 < len(remaining) {
-			columnFilter = remaining[i+1]
-			break
+		switch remaining[i] {
+		case "--column":
+			if i+1 < len(remaining) {
+				columnFilter = remaining[i+1]
+				i++
+			}
+		case "--assignee":
+			if i+1 < len(remaining) {
+				filter.Assignee = remaining[i+1]
+				i++
+			}
+		case "--due-before":
+			if i+1 < len(remaining) {
+				dueBefore, err := parseDueDate(remaining[i+1], now)
+				if err != nil {
+					return fmt.Errorf("--due-before: %w", err)
+				}
+				filter.DueBefore = dueBefore
+				i++
+			}
+		case "--sort":
+			if i+1 < len(remaining) {
+				sortBy = remaining[i+1]
+				i++
+			}
+		case "--overdue":
+			filter.Overdue = true
+		case "--mine":
+			mine = true
+		case "--unassigned":
+			filter.Unassigned = true
 		}
 	}
 
+	if sortBy != "position" && sortBy != "due" && sortBy != "updated" {
+		return errors.New("--sort must be one of: position, due, updated")
+	}
+	if mine && filter.Unassigned {
+		return errors.New("--mine and --unassigned cannot be combined")
+	}
+
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
+	if mine {
+		me, err := fetchMyProfile(cl)
+		if err != nil {
+			return err
+		}
+		filter.PersonID = me.ID
+	}
+
 	// Get the card table
 	data, err := cl.Get("/buckets/" + projectID + "/card_tables/" + boardID + ".json")
 	if err != nil {
@@ -106,34 +299,29 @@ func (c *CardsCmd) Run(args []string) error {
 			continue
 		}
 
-		// Fetch cards from this column
-		cardsData, err := cl.Get(list.CardsURL)
+		cards, err := fetchCards(cl, list.CardsURL)
 		if err != nil {
 			return err
 		}
 
-		var cards []CardSummary
-		if err := json.Unmarshal(cardsData, &cards); err != nil {
-			return err
-		}
-
 		columnCards := ColumnCards{
 			Column: list.Title,
-			Cards:  make([]CardOutput, len(cards)),
+			Cards:  []CardOutput{},
 		}
 
-		for i, card := range cards {
-			creator := "Unknown"
-			if card.Creator.Name != "" {
-				creator = card.Creator.Name
-			}
-			columnCards.Cards[i] = CardOutput{
-				ID:      card.ID,
-				Title:   card.Title,
-				Creator: creator,
+		for _, card := range cards {
+			if !filter.matches(card) {
+				continue
 			}
+			columnCards.Cards = append(columnCards.Cards, cardToOutput(card))
+		}
+
+		// Columns with no matching cards are noise when filtering
+		if filter.active() && len(columnCards.Cards) == 0 {
+			continue
 		}
 
+		sortCards(columnCards.Cards, sortBy)
 		output.Columns = append(output.Columns, columnCards)
 	}
 
@@ -205,10 +393,11 @@ func (c *ColumnsCmd) Run(args []string) error {
 type CardCreateCmd struct{}
 
 type CardCreateOutput struct {
-	Status  string `json:"status"`
-	ID      int    `json:"id"`
-	Title   string `json:"title"`
-	Message string `json:"message"`
+	Status          string   `json:"status"`
+	ID              int      `json:"id"`
+	Title           string   `json:"title"`
+	AttachableSGIDs []string `json:"attachable_sgids,omitempty"`
+	Message         string   `json:"message"`
 }
 
 func (c *CardCreateCmd) Run(args []string) error {
@@ -218,7 +407,9 @@ func (c *CardCreateCmd) Run(args []string) error {
 	}
 
 	// Parse board_id, column_id, and flags
-	var boardID, columnID, title, content, dueOn string
+	var boardID, columnID, title, dueOn string
+	var cf contentFlags
+	var af attachFlags
 
 	for i := 0; i < len(remaining); i++ {
 		switch remaining[i] {
@@ -232,17 +423,15 @@ func (c *CardCreateCmd) Run(args []string) error {
 				title = remaining[i+1]
 				i++
 			}
-		case "--content":
-			if i+1 < len(remaining) {
-				content = remaining[i+1]
-				i++
-			}
 		case "--due":
 			if i+1 < len(remaining) {
 				dueOn = remaining[i+1]
 				i++
 			}
 		default:
+			if cf.parse(remaining, &i) || af.parse(remaining, &i) {
+				continue
+			}
 			if boardID == "" {
 				boardID = remaining[i]
 			}
@@ -258,12 +447,24 @@ func (c *CardCreateCmd) Run(args []string) error {
 	if title == "" {
 		return errors.New("--title required")
 	}
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
 
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+	content, sgids, err := af.embed(cl, content)
+	if err != nil {
+		return err
+	}
+
 	// Create card
 	payload := map[string]any{
 		"title": title,
@@ -287,10 +488,11 @@ func (c *CardCreateCmd) Run(args []string) error {
 	}
 
 	return PrintJSON(CardCreateOutput{
-		Status:  "ok",
-		ID:      created.ID,
-		Title:   created.Title,
-		Message: fmt.Sprintf("Card '%s' created", created.Title),
+		Status:          "ok",
+		ID:              created.ID,
+		Title:           created.Title,
+		AttachableSGIDs: sgids,
+		Message:         fmt.Sprintf("Card '%s' created", created.Title),
 	})
 }
 
@@ -311,7 +513,8 @@ func (c *CardUpdateCmd) Run(args []string) error {
 	}
 
 	// Parse card_id and flags
-	var cardID, title, content, dueOn string
+	var cardID, title, dueOn string
+	var cf contentFlags
 
 	for i := 0; i < len(remaining); i++ {
 		switch remaining[i] {
@@ -320,17 +523,15 @@ func (c *CardUpdateCmd) Run(args []string) error {
 				title = remaining[i+1]
 				i++
 			}
-		case "--content":
-			if i+1 < len(remaining) {
-				content = remaining[i+1]
-				i++
-			}
 		case "--due":
 			if i+1 < len(remaining) {
 				dueOn = remaining[i+1]
 				i++
 			}
 		default:
+			if cf.parse(remaining, &i) {
+				continue
+			}
 			if cardID == "" {
 				cardID = remaining[i]
 			}
@@ -340,15 +541,23 @@ func (c *CardUpdateCmd) Run(args []string) error {
 	if cardID == "" {
 		return errors.New("card_id required")
 	}
-	if title == "" && content == "" && dueOn == "" {
+	if title == "" && !cf.given() && dueOn == "" {
 		return errors.New("at least one of --title, --content, or --due required")
 	}
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
 
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+
 	// Update card
 	payload := map[string]any{}
 	if title != "" {
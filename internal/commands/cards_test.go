@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+func TestCardFilterMatches(t *testing.T) {
+	alice := Assignee{ID: 1, Name: "Alice Smith"}
+	bob := Assignee{ID: 2, Name: "Bob Jones"}
+
+	tests := []struct {
+		name   string
+		filter cardFilter
+		card   CardSummary
+		want   bool
+	}{
+		{
+			name:   "no filters",
+			filter: cardFilter{},
+			card:   CardSummary{ID: 1},
+			want:   true,
+		},
+		{
+			name:   "assignee by name",
+			filter: cardFilter{Assignee: "alice"},
+			card:   CardSummary{Assignees: []Assignee{bob, alice}},
+			want:   true,
+		},
+		{
+			name:   "assignee by id",
+			filter: cardFilter{Assignee: "2"},
+			card:   CardSummary{Assignees: []Assignee{bob}},
+			want:   true,
+		},
+		{
+			name:   "assignee no match",
+			filter: cardFilter{Assignee: "carol"},
+			card:   CardSummary{Assignees: []Assignee{alice, bob}},
+			want:   false,
+		},
+		{
+			name:   "mine",
+			filter: cardFilter{PersonID: 1},
+			card:   CardSummary{Assignees: []Assignee{alice}},
+			want:   true,
+		},
+		{
+			name:   "mine not assigned",
+			filter: cardFilter{PersonID: 1},
+			card:   CardSummary{Assignees: []Assignee{bob}},
+			want:   false,
+		},
+		{
+			name:   "unassigned",
+			filter: cardFilter{Unassigned: true},
+			card:   CardSummary{},
+			want:   true,
+		},
+		{
+			name:   "unassigned with assignee",
+			filter: cardFilter{Unassigned: true},
+			card:   CardSummary{Assignees: []Assignee{alice}},
+			want:   false,
+		},
+		{
+			name:   "due before",
+			filter: cardFilter{DueBefore: "2026-02-01"},
+			card:   CardSummary{DueOn: "2026-01-15"},
+			want:   true,
+		},
+		{
+			name:   "due before excludes same day",
+			filter: cardFilter{DueBefore: "2026-02-01"},
+			card:   CardSummary{DueOn: "2026-02-01"},
+			want:   false,
+		},
+		{
+			name:   "due before excludes undated",
+			filter: cardFilter{DueBefore: "2026-02-01"},
+			card:   CardSummary{},
+			want:   false,
+		},
+		{
+			name:   "overdue",
+			filter: cardFilter{Overdue: true, Today: "2026-03-01"},
+			card:   CardSummary{DueOn: "2026-02-28"},
+			want:   true,
+		},
+		{
+			name:   "overdue but completed",
+			filter: cardFilter{Overdue: true, Today: "2026-03-01"},
+			card:   CardSummary{DueOn: "2026-02-28", Completed: true},
+			want:   false,
+		},
+		{
+			name:   "due today is not overdue",
+			filter: cardFilter{Overdue: true, Today: "2026-03-01"},
+			card:   CardSummary{DueOn: "2026-03-01"},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.card); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardToOutput(t *testing.T) {
+	card := CardSummary{
+		ID:            7,
+		Title:         "Ship it",
+		DueOn:         "2026-01-01",
+		CommentsCount: 3,
+		Assignees:     []Assignee{{ID: 1, Name: "Alice"}},
+		Steps:         []Step{{Completed: true}, {Completed: false}, {Completed: true}},
+	}
+
+	got := cardToOutput(card)
+	if got.Creator != "Unknown" {
+		t.Errorf("Creator = %q, want %q", got.Creator, "Unknown")
+	}
+	if got.StepsCompleted != 2 || got.StepsTotal != 3 {
+		t.Errorf("steps = %d/%d, want 2/3", got.StepsCompleted, got.StepsTotal)
+	}
+	if len(got.Assignees) != 1 || got.Assignees[0] != "Alice" {
+		t.Errorf("Assignees = %v, want [Alice]", got.Assignees)
+	}
+	if got.CommentsCount != 3 {
+		t.Errorf("CommentsCount = %d, want 3", got.CommentsCount)
+	}
+}
+
+func TestSortCards(t *testing.T) {
+	cards := func() []CardOutput {
+		return []CardOutput{
+			{ID: 1, DueOn: "", UpdatedAt: "2026-01-02T00:00:00Z"},
+			{ID: 2, DueOn: "2026-03-01", UpdatedAt: "2026-01-03T00:00:00Z"},
+			{ID: 3, DueOn: "2026-02-01", UpdatedAt: "2026-01-01T00:00:00Z"},
+		}
+	}
+
+	tests := []struct {
+		by   string
+		want []int
+	}{
+		{"position", []int{1, 2, 3}},
+		{"due", []int{3, 2, 1}},
+		{"updated", []int{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			got := cards()
+			sortCards(got, tt.by)
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("sortCards(%q) order = %v, want %v", tt.by, cardIDs(got), tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func cardIDs(cards []CardOutput) []int {
+	out := make([]int, len(cards))
+	for i, c := range cards {
+		out[i] = c.ID
+	}
+	return out
+}
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "2026-02-01", want: "2026-02-01"},
+		{in: "tomorrow", want: "2026-01-16"},
+		{in: "+2w", want: "2026-01-29"},
+		{in: "2024-13-01", wantErr: true},
+		{in: "tomorow", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDueDate(tt.in, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDueDate(%q) = %q, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDueDate(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDueDate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFetchCardsFollowsPages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":3,"title":"Third"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/buckets/1/card_tables/lists/2/cards.json?page=2>; rel="next"`, srv.URL))
+		fmt.Fprint(w, `[{"id":1,"title":"First"},{"id":2,"title":"Second"}]`)
+	}))
+	defer srv.Close()
+
+	cards, err := fetchCards(client.NewWithBaseURL(srv.URL, "token"), srv.URL+"/buckets/1/card_tables/lists/2/cards.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 3 || cards[2].Title != "Third" {
+		t.Errorf("fetchCards() = %+v, want the cards from both pages", cards)
+	}
+}
@@ -0,0 +1,521 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+// Chatbot is a campfire integration. Its lines URL carries the bot key, so
+// posting to it needs no OAuth token.
+type Chatbot struct {
+	ID          int    `json:"id"`
+	ServiceName string `json:"service_name"`
+	CommandURL  string `json:"command_url"`
+	URL         string `json:"url"`
+	AppURL      string `json:"app_url"`
+	LinesURL    string `json:"lines_url"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type ChatbotBrief struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	CommandURL string `json:"command_url,omitempty"`
+	LinesURL   string `json:"lines_url"`
+	SavedAs    string `json:"saved_as,omitempty"`
+}
+
+type ChatbotsOutput struct {
+	ProjectID  int            `json:"project_id"`
+	CampfireID int            `json:"campfire_id"`
+	Chatbots   []ChatbotBrief `json:"chatbots"`
+}
+
+type ChatbotWriteOutput struct {
+	Status   string `json:"status"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	LinesURL string `json:"lines_url"`
+	SavedAs  string `json:"saved_as,omitempty"`
+	Message  string `json:"message"`
+}
+
+// chatbotsPath returns the integrations path of the project's campfire
+func chatbotsPath(cl *client.Client, projectID string) (Campfire, string, error) {
+	_, campfire, err := fetchCampfire(cl, projectID)
+	if err != nil {
+		return Campfire{}, "", err
+	}
+	return campfire, fmt.Sprintf("/buckets/%s/chats/%d/integrations", projectID, campfire.ID), nil
+}
+
+func fetchChatbots(cl *client.Client, path string) ([]Chatbot, error) {
+	data, err := cl.Get(path + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var chatbots []Chatbot
+	if err := json.Unmarshal(data, &chatbots); err != nil {
+		return nil, err
+	}
+	return chatbots, nil
+}
+
+// savedChatbotNames maps chatbot lines URLs to the names they are saved
+// under in the config
+func savedChatbotNames() map[string]string {
+	names := map[string]string{}
+	if cfg, err := config.Load(); err == nil {
+		for name, url := range cfg.Chatbots {
+			names[url] = name
+		}
+	}
+	return names
+}
+
+// saveChatbotURL stores a chatbot lines URL in the config under name
+func saveChatbotURL(name, url string) error {
+	cfg, err := config.Load()
+	if errors.Is(err, config.ErrConfigNotFound) {
+		cfg, err = &config.Config{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if cfg.Chatbots == nil {
+		cfg.Chatbots = map[string]string{}
+	}
+	cfg.Chatbots[name] = url
+	return config.Save(cfg)
+}
+
+// resolveChatbotURL returns the lines URL of a chatbot saved under name
+func resolveChatbotURL(name string) (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+
+	if url, ok := cfg.Chatbots[name]; ok {
+		return url, nil
+	}
+
+	names := make([]string, 0, len(cfg.Chatbots))
+	for n := range cfg.Chatbots {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return "", fmt.Errorf("chatbot '%s' not found: no chatbots saved in %s", name, config.ConfigFile())
+	}
+	return "", fmt.Errorf("chatbot '%s' not found. Available chatbots: %s", name, strings.Join(names, ", "))
+}
+
+// ChatbotsCmd lists the chatbots of the account, as seen from a campfire
+type ChatbotsCmd struct{}
+
+func (c *ChatbotsCmd) Run(args []string) error {
+	projectID, _, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	campfire, path, err := chatbotsPath(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	chatbots, err := fetchChatbots(cl, path)
+	if err != nil {
+		return err
+	}
+
+	pID, _ := strconv.Atoi(projectID)
+	output := ChatbotsOutput{
+		ProjectID:  pID,
+		CampfireID: campfire.ID,
+		Chatbots:   make([]ChatbotBrief, len(chatbots)),
+	}
+
+	saved := savedChatbotNames()
+	for i, bot := range chatbots {
+		output.Chatbots[i] = ChatbotBrief{
+			ID:         bot.ID,
+			Name:       bot.ServiceName,
+			CommandURL: bot.CommandURL,
+			LinesURL:   bot.LinesURL,
+			SavedAs:    saved[bot.LinesURL],
+		}
+	}
+
+	return PrintJSON(output)
+}
+
+// ChatbotCreateCmd creates a chatbot
+type ChatbotCreateCmd struct{}
+
+func (c *ChatbotCreateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var name, commandURL, saveAs string
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--name":
+			if i+1 < len(remaining) {
+				name = remaining[i+1]
+				i++
+			}
+		case "--command-url":
+			if i+1 < len(remaining) {
+				commandURL = remaining[i+1]
+				i++
+			}
+		case "--save":
+			if i+1 < len(remaining) {
+				saveAs = remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	if name == "" {
+		return errors.New("--name required")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, path, err := chatbotsPath(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{"service_name": name}
+	if commandURL != "" {
+		payload["command_url"] = commandURL
+	}
+
+	data, err := cl.Post(path+".json", payload)
+	if err != nil {
+		return err
+	}
+
+	var bot Chatbot
+	if err := json.Unmarshal(data, &bot); err != nil {
+		return err
+	}
+
+	if saveAs != "" {
+		if err := saveChatbotURL(saveAs, bot.LinesURL); err != nil {
+			return fmt.Errorf("failed to save chatbot: %w", err)
+		}
+	}
+
+	return PrintJSON(ChatbotWriteOutput{
+		Status:   "ok",
+		ID:       bot.ID,
+		Name:     bot.ServiceName,
+		LinesURL: bot.LinesURL,
+		SavedAs:  saveAs,
+		Message:  fmt.Sprintf("Chatbot '%s' created", bot.ServiceName),
+	})
+}
+
+// ChatbotUpdateCmd renames a chatbot or changes its command URL
+type ChatbotUpdateCmd struct{}
+
+func (c *ChatbotUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp chatbot-update [project_id] <chatbot_id> [--name <name>] [--command-url <url>]")
+	}
+	chatbotID := remaining[0]
+
+	payload := map[string]any{}
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--name":
+			if i+1 < len(remaining) {
+				payload["service_name"] = remaining[i+1]
+				i++
+			}
+		case "--command-url":
+			if i+1 < len(remaining) {
+				payload["command_url"] = remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	if len(payload) == 0 {
+		return errors.New("at least one of --name or --command-url required")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, path, err := chatbotsPath(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Put(path+"/"+chatbotID+".json", payload)
+	if err != nil {
+		return err
+	}
+
+	var bot Chatbot
+	if err := json.Unmarshal(data, &bot); err != nil {
+		return err
+	}
+
+	return PrintJSON(ChatbotWriteOutput{
+		Status:   "ok",
+		ID:       bot.ID,
+		Name:     bot.ServiceName,
+		LinesURL: bot.LinesURL,
+		Message:  fmt.Sprintf("Chatbot '%s' updated", bot.ServiceName),
+	})
+}
+
+// ChatbotDeleteCmd deletes a chatbot from the account
+type ChatbotDeleteCmd struct{}
+
+func (c *ChatbotDeleteCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp chatbot-delete [project_id] <chatbot_id>")
+	}
+	chatbotID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, path, err := chatbotsPath(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cl.Delete(path + "/" + chatbotID + ".json"); err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":     "ok",
+		"chatbot_id": chatbotID,
+		"message":    "Chatbot deleted",
+	})
+}
+
+// ChatbotSaveCmd stores a chatbot's lines URL in the config so
+// chatbot-post --bot can use it
+type ChatbotSaveCmd struct{}
+
+func (c *ChatbotSaveCmd) Run(args []string) error {
+	var url, saveAs string
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--url":
+			if i+1 < len(args) {
+				url = args[i+1]
+				i++
+			}
+		case "--as":
+			if i+1 < len(args) {
+				saveAs = args[i+1]
+				i++
+			}
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	// A URL copied from Basecamp needs no lookup
+	if url != "" {
+		if saveAs == "" {
+			return errors.New("--as required with --url")
+		}
+		if err := saveChatbotURL(saveAs, url); err != nil {
+			return fmt.Errorf("failed to save chatbot: %w", err)
+		}
+		return PrintJSON(ChatbotWriteOutput{
+			Status:   "ok",
+			LinesURL: url,
+			SavedAs:  saveAs,
+			Message:  fmt.Sprintf("Chatbot saved as '%s'", saveAs),
+		})
+	}
+
+	projectID, remaining, err := getProjectID(rest)
+	if err != nil {
+		return err
+	}
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp chatbot-save [project_id] <chatbot_id|name> [--as <name>] or chatbot-save --url <lines_url> --as <name>")
+	}
+	ref := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, path, err := chatbotsPath(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	chatbots, err := fetchChatbots(cl, path)
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i, bot := range chatbots {
+			if bot.ID == id {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			return fmt.Errorf("chatbot %d not found", id)
+		}
+	} else {
+		names := make([]string, len(chatbots))
+		for i, bot := range chatbots {
+			names[i] = bot.ServiceName
+		}
+		if idx, err = matchByName("chatbot", ref, names); err != nil {
+			return err
+		}
+	}
+	bot := chatbots[idx]
+
+	if saveAs == "" {
+		saveAs = bot.ServiceName
+	}
+	if err := saveChatbotURL(saveAs, bot.LinesURL); err != nil {
+		return fmt.Errorf("failed to save chatbot: %w", err)
+	}
+
+	return PrintJSON(ChatbotWriteOutput{
+		Status:   "ok",
+		ID:       bot.ID,
+		Name:     bot.ServiceName,
+		LinesURL: bot.LinesURL,
+		SavedAs:  saveAs,
+		Message:  fmt.Sprintf("Chatbot '%s' saved as '%s'", bot.ServiceName, saveAs),
+	})
+}
+
+// ChatbotPostCmd posts a campfire line as a chatbot. It only needs the
+// bot's URL, not a token from basecamp auth.
+type ChatbotPostCmd struct{}
+
+type ChatbotPostOutput struct {
+	Status  string `json:"status"`
+	ID      int    `json:"id,omitempty"`
+	Message string `json:"message"`
+}
+
+func (c *ChatbotPostCmd) Run(args []string) error {
+	var botURL, botName string
+	var cf contentFlags
+
+	for i := 0; i < len(args); i++ {
+		if cf.parse(args, &i) {
+			continue
+		}
+		switch args[i] {
+		case "--bot-url":
+			if i+1 < len(args) {
+				botURL = args[i+1]
+				i++
+			}
+		case "--bot":
+			if i+1 < len(args) {
+				botName = args[i+1]
+				i++
+			}
+		}
+	}
+
+	if botURL != "" && botName != "" {
+		return errors.New("--bot-url cannot be combined with --bot")
+	}
+	if botURL == "" && botName == "" {
+		// CI systems usually pass secrets through the environment
+		botURL = os.Getenv("BASECAMP_CHATBOT_URL")
+	}
+	if botURL == "" && botName == "" {
+		return errors.New("--bot-url or --bot required (or set BASECAMP_CHATBOT_URL)")
+	}
+
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		return errors.New("--content, --content-file or --stdin required")
+	}
+
+	if botName != "" {
+		if botURL, err = resolveChatbotURL(botName); err != nil {
+			return err
+		}
+	}
+
+	// Chatbot lines are rich text, so HTML like <strong> or <a> is kept
+	data, err := client.NewUnauthenticated().Post(botURL, map[string]string{"content": content})
+	if err != nil {
+		return err
+	}
+
+	output := ChatbotPostOutput{
+		Status:  "ok",
+		Message: "Message posted to campfire",
+	}
+	if len(data) > 0 {
+		var line CampfireLine
+		if err := json.Unmarshal(data, &line); err == nil {
+			output.ID = line.ID
+		}
+	}
+
+	return PrintJSON(output)
+}
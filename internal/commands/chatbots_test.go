@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChatbotConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, err := resolveChatbotURL("ci"); err == nil {
+		t.Fatal("expected an error without a config file")
+	}
+
+	url := "https://3.basecampapi.com/1/integrations/key/buckets/2/chats/3/lines.json"
+	if err := saveChatbotURL("ci", url); err != nil {
+		t.Fatalf("saveChatbotURL() error: %v", err)
+	}
+	if err := saveChatbotURL("deploys", url+"?deploys"); err != nil {
+		t.Fatalf("saveChatbotURL() error: %v", err)
+	}
+
+	got, err := resolveChatbotURL("ci")
+	if err != nil || got != url {
+		t.Errorf("resolveChatbotURL(ci) = %q, %v, want %q", got, err, url)
+	}
+
+	_, err = resolveChatbotURL("alerts")
+	if err == nil || !strings.Contains(err.Error(), "Available chatbots: ci, deploys") {
+		t.Errorf("expected the saved chatbots in the error, got %v", err)
+	}
+
+	if names := savedChatbotNames(); names[url] != "ci" {
+		t.Errorf("savedChatbotNames()[url] = %q, want ci", names[url])
+	}
+}
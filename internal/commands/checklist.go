@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// checklistItem is one line of a markdown checklist such as
+// "- [ ] Write tests @jane due:2026-01-01"
+type checklistItem struct {
+	Title       string
+	Completed   bool
+	Assignees   []string
+	DueOn       string
+	StartsOn    string
+	Description string
+}
+
+// checklistSection is a markdown heading and the checklist items under it.
+// Items before the first heading have an empty Heading. List names the
+// todolist the section belongs to when importing several lists at once.
+type checklistSection struct {
+	List    string
+	Heading string
+	Items   []checklistItem
+}
+
+var checklistLineRegex = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s+(.*)$`)
+var dueTokenRegex = regexp.MustCompile(`^due:(\d{4}-\d{2}-\d{2})$`)
+var startsTokenRegex = regexp.MustCompile(`^starts:(\d{4}-\d{2}-\d{2})$`)
+var headingRegex = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+var todolistHeadingRegex = regexp.MustCompile(`^#\s+(.*?)\s*#*$`)
+
+// parseChecklistLine parses a single markdown checklist line. The second
+// return value is false if the line is not a checklist item.
+func parseChecklistLine(line string) (checklistItem, bool) {
+	matches := checklistLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return checklistItem{}, false
+	}
+
+	item := checklistItem{Completed: matches[1] != " "}
+
+	var words []string
+	for _, word := range strings.Fields(matches[2]) {
+		if strings.HasPrefix(word, "@") && len(word) > 1 {
+			item.Assignees = append(item.Assignees, word[1:])
+			continue
+		}
+		if m := dueTokenRegex.FindStringSubmatch(word); m != nil {
+			item.DueOn = m[1]
+			continue
+		}
+		if m := startsTokenRegex.FindStringSubmatch(word); m != nil {
+			item.StartsOn = m[1]
+			continue
+		}
+		words = append(words, word)
+	}
+	item.Title = strings.Join(words, " ")
+
+	if item.Title == "" {
+		return checklistItem{}, false
+	}
+	return item, true
+}
+
+// parseChecklist extracts checklist items from markdown text, ignoring
+// any line that is not a "- [ ]" or "- [x]" item.
+func parseChecklist(text string) []checklistItem {
+	var items []checklistItem
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if item, ok := parseChecklistLine(scanner.Text()); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseChecklistSections extracts checklist items grouped under their
+// markdown headings. Indented lines directly below an item become its
+// description.
+func parseChecklistSections(text string) []checklistSection {
+	sections := []checklistSection{{}}
+	var current *checklistItem
+	var description []string
+
+	flush := func() {
+		if current != nil {
+			current.Description = strings.TrimSpace(strings.Join(description, "\n"))
+		}
+		current = nil
+		description = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if item, ok := parseChecklistLine(line); ok {
+			flush()
+			section := &sections[len(sections)-1]
+			section.Items = append(section.Items, item)
+			current = &section.Items[len(section.Items)-1]
+			continue
+		}
+
+		if m := headingRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			flush()
+			sections = append(sections, checklistSection{Heading: m[1]})
+			continue
+		}
+
+		if current != nil && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t")) {
+			description = append(description, strings.TrimSpace(line))
+			continue
+		}
+
+		flush()
+	}
+	flush()
+
+	// Drop headings without items
+	var result []checklistSection
+	for _, section := range sections {
+		if len(section.Items) > 0 {
+			result = append(result, section)
+		}
+	}
+	return result
+}
+
+// parseTodolistSections reads markdown with several todolists, as written
+// by todos-export --all: each "# Title" heading starts a todolist and
+// deeper headings are groups within it
+func parseTodolistSections(text string) []checklistSection {
+	var sections []checklistSection
+	var list string
+	var lines []string
+
+	flush := func() {
+		for _, section := range parseChecklistSections(strings.Join(lines, "\n")) {
+			section.List = list
+			sections = append(sections, section)
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := todolistHeadingRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			flush()
+			list = m[1]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return sections
+}
+
+// normalizeTitle is used to compare titles when skipping existing items
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecklistLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want checklistItem
+		ok   bool
+	}{
+		{
+			name: "plain item",
+			line: "- [ ] Write tests",
+			want: checklistItem{Title: "Write tests"},
+			ok:   true,
+		},
+		{
+			name: "completed item",
+			line: "- [x] Ship it",
+			want: checklistItem{Title: "Ship it", Completed: true},
+			ok:   true,
+		},
+		{
+			name: "uppercase X and star bullet",
+			line: "* [X] Review",
+			want: checklistItem{Title: "Review", Completed: true},
+			ok:   true,
+		},
+		{
+			name: "assignees and due date",
+			line: "- [ ] Deploy to staging @jane @bob due:2026-01-01",
+			want: checklistItem{Title: "Deploy to staging", Assignees: []string{"jane", "bob"}, DueOn: "2026-01-01"},
+			ok:   true,
+		},
+		{
+			name: "start date",
+			line: "- [ ] Migrate data starts:2026-01-05 due:2026-01-09",
+			want: checklistItem{Title: "Migrate data", StartsOn: "2026-01-05", DueOn: "2026-01-09"},
+			ok:   true,
+		},
+		{
+			name: "indented",
+			line: "   - [ ] Nested item",
+			want: checklistItem{Title: "Nested item"},
+			ok:   true,
+		},
+		{
+			name: "invalid due date kept in title",
+			line: "- [ ] Plan due:tomorrow",
+			want: checklistItem{Title: "Plan due:tomorrow"},
+			ok:   true,
+		},
+		{
+			name: "plain bullet",
+			line: "- not a checklist",
+			ok:   false,
+		},
+		{
+			name: "heading",
+			line: "# Definition of done",
+			ok:   false,
+		},
+		{
+			name: "only tokens",
+			line: "- [ ] @jane due:2026-01-01",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseChecklistLine(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("parseChecklistLine(%q) ok = %v, want %v", tt.line, ok, tt.ok)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChecklistLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChecklist(t *testing.T) {
+	text := `# Definition of done
+
+Some intro text.
+
+- [ ] Tests pass
+- [x] Code reviewed @jane
+- plain bullet
+- [ ] Docs updated due:2026-02-01
+`
+	items := parseChecklist(text)
+	if len(items) != 3 {
+		t.Fatalf("parseChecklist() returned %d items, want 3", len(items))
+	}
+
+	titles := []string{items[0].Title, items[1].Title, items[2].Title}
+	want := []string{"Tests pass", "Code reviewed", "Docs updated"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %v, want %v", titles, want)
+	}
+	if !items[1].Completed {
+		t.Error("expected second item to be completed")
+	}
+}
+
+func TestParseChecklistSections(t *testing.T) {
+	text := `- [ ] Kickoff @jane
+  Agenda in the shared doc.
+  Bring laptops.
+
+## Week 1 ##
+
+- [x] Set up repo
+- [ ] Write spec due:2026-02-01
+	First draft only
+Not part of the description
+
+## Empty section
+
+# Week 2
+- [ ] Build it
+`
+	sections := parseChecklistSections(text)
+
+	headings := make([]string, len(sections))
+	for i, s := range sections {
+		headings[i] = s.Heading
+	}
+	if want := []string{"", "Week 1", "Week 2"}; !reflect.DeepEqual(headings, want) {
+		t.Fatalf("headings = %q, want %q", headings, want)
+	}
+
+	kickoff := sections[0].Items[0]
+	if kickoff.Description != "Agenda in the shared doc.\nBring laptops." {
+		t.Errorf("kickoff description = %q", kickoff.Description)
+	}
+	if !reflect.DeepEqual(kickoff.Assignees, []string{"jane"}) {
+		t.Errorf("kickoff assignees = %v", kickoff.Assignees)
+	}
+
+	week1 := sections[1].Items
+	if len(week1) != 2 || !week1[0].Completed || week1[0].Description != "" {
+		t.Fatalf("unexpected week 1 items: %+v", week1)
+	}
+	if week1[1].Description != "First draft only" || week1[1].DueOn != "2026-02-01" {
+		t.Errorf("unexpected spec item: %+v", week1[1])
+	}
+
+	if len(sections[2].Items) != 1 || sections[2].Items[0].Title != "Build it" {
+		t.Errorf("unexpected week 2 items: %+v", sections[2].Items)
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	if normalizeTitle("  Tests   Pass ") != normalizeTitle("tests pass") {
+		t.Error("expected titles differing in case and whitespace to match")
+	}
+}
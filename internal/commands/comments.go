@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -11,10 +14,11 @@ import (
 type CommentAddCmd struct{}
 
 type CommentAddOutput struct {
-	Status      string `json:"status"`
-	ID          int    `json:"id"`
-	RecordingID string `json:"recording_id"`
-	Message     string `json:"message"`
+	Status          string   `json:"status"`
+	ID              int      `json:"id"`
+	RecordingID     string   `json:"recording_id"`
+	AttachableSGIDs []string `json:"attachable_sgids,omitempty"`
+	Message         string   `json:"message"`
 }
 
 func (c *CommentAddCmd) Run(args []string) error {
@@ -23,27 +27,29 @@ func (c *CommentAddCmd) Run(args []string) error {
 		return err
 	}
 
-	// Parse recording_id and --content flag
-	var recordingID, content string
+	// Parse recording_id and content flags
+	var recordingID string
+	var cf contentFlags
+	var af attachFlags
 
 	for i := 0; i < len(remaining); i++ {
-		switch remaining[i] {
-		case "--content":
-			if i+1 < len(remaining) {
-				content = remaining[i+1]
-				i++
-			}
-		default:
-			if recordingID == "" {
-				recordingID = remaining[i]
-			}
+		if cf.parse(remaining, &i) || af.parse(remaining, &i) {
+			continue
+		}
+		if recordingID == "" {
+			recordingID = remaining[i]
 		}
 	}
 
 	if recordingID == "" {
 		return errors.New("recording_id required")
 	}
-	if content == "" {
+
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
+	if content == "" && len(af.Paths) == 0 {
 		return errors.New("--content required")
 	}
 
@@ -52,6 +58,14 @@ func (c *CommentAddCmd) Run(args []string) error {
 		return err
 	}
 
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+	content, sgids, err := af.embed(cl, content)
+	if err != nil {
+		return err
+	}
+
 	// Create comment
 	payload := map[string]string{
 		"content": content,
@@ -69,9 +83,288 @@ func (c *CommentAddCmd) Run(args []string) error {
 	}
 
 	return PrintJSON(CommentAddOutput{
-		Status:      "ok",
-		ID:          created.ID,
+		Status:          "ok",
+		ID:              created.ID,
+		RecordingID:     recordingID,
+		AttachableSGIDs: sgids,
+		Message:         fmt.Sprintf("Comment added to recording %s", recordingID),
+	})
+}
+
+// CommentsCmd lists the comments on any recording
+type CommentsCmd struct{}
+
+type CommentsOutput struct {
+	RecordingID string          `json:"recording_id"`
+	Page        int             `json:"page,omitempty"`
+	NextPage    int             `json:"next_page,omitempty"`
+	Comments    []CommentOutput `json:"comments"`
+}
+
+func commentToOutput(comment Comment, body string) CommentOutput {
+	author := "Unknown"
+	if comment.Creator.Name != "" {
+		author = comment.Creator.Name
+	}
+	return CommentOutput{
+		ID:        comment.ID,
+		Author:    author,
+		Content:   renderBody(body, comment.Content),
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+// commentCreatedAfter reports whether a comment was created after since
+func commentCreatedAfter(comment Comment, since time.Time) bool {
+	created, err := time.Parse(time.RFC3339, comment.CreatedAt)
+	return err == nil && created.After(since)
+}
+
+func (c *CommentsCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var recordingID, sinceArg string
+	page := 0
+	body := bodyMarkdown
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--since":
+			if i+1 < len(remaining) {
+				sinceArg = remaining[i+1]
+				i++
+			}
+		case "--body":
+			if i+1 < len(remaining) {
+				if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+					return err
+				}
+				i++
+			}
+		case "--page":
+			if i+1 < len(remaining) {
+				n, err := strconv.Atoi(remaining[i+1])
+				if err != nil || n < 1 {
+					return errors.New("--page must be a positive number")
+				}
+				page = n
+				i++
+			}
+		default:
+			if recordingID == "" {
+				recordingID = remaining[i]
+			}
+		}
+	}
+
+	if recordingID == "" {
+		return errors.New("usage: basecamp comments [project_id] <recording_id> [--since <time>] [--page <n>] [--body markdown|text|html]")
+	}
+
+	var since time.Time
+	if sinceArg != "" {
+		if since, err = parseNaturalTime(sinceArg, time.Now()); err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/buckets/%s/recordings/%s/comments.json", projectID, recordingID)
+	output := CommentsOutput{
 		RecordingID: recordingID,
-		Message:     fmt.Sprintf("Comment added to recording %s", recordingID),
+		Page:        page,
+		Comments:    []CommentOutput{},
+	}
+
+	var pages []json.RawMessage
+	if page > 0 {
+		// One page, for callers that page through long threads themselves
+		data, next, err := cl.GetPage(context.Background(), path+"?page="+strconv.Itoa(page))
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &pages); err != nil {
+			return err
+		}
+		if next != "" {
+			output.NextPage = page + 1
+		}
+	} else if pages, err = cl.GetAll(path); err != nil {
+		return err
+	}
+
+	for _, commentJSON := range pages {
+		var comment Comment
+		if err := json.Unmarshal(commentJSON, &comment); err != nil {
+			return err
+		}
+		if !since.IsZero() && !commentCreatedAfter(comment, since) {
+			continue
+		}
+		output.Comments = append(output.Comments, commentToOutput(comment, body))
+	}
+
+	return PrintJSON(output)
+}
+
+// CommentCmd shows a single comment
+type CommentCmd struct{}
+
+type CommentDetailOutput struct {
+	ID          int    `json:"id"`
+	Author      string `json:"author"`
+	Content     string `json:"content"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	URL         string `json:"url"`
+	ParentID    int    `json:"parent_id"`
+	ParentTitle string `json:"parent_title"`
+	ParentType  string `json:"parent_type"`
+}
+
+func (c *CommentCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("comment_id required")
+	}
+	commentID := remaining[0]
+
+	body := bodyMarkdown
+	for i := 1; i < len(remaining); i++ {
+		if remaining[i] == "--body" && i+1 < len(remaining) {
+			if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Get("/buckets/" + projectID + "/comments/" + commentID + ".json")
+	if err != nil {
+		return err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(data, &comment); err != nil {
+		return err
+	}
+
+	brief := commentToOutput(comment, body)
+	return PrintJSON(CommentDetailOutput{
+		ID:          comment.ID,
+		Author:      brief.Author,
+		Content:     brief.Content,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+		URL:         comment.AppURL,
+		ParentID:    comment.Parent.ID,
+		ParentTitle: comment.Parent.Title,
+		ParentType:  comment.Parent.Type,
+	})
+}
+
+// CommentUpdateCmd replaces the content of a comment
+type CommentUpdateCmd struct{}
+
+func (c *CommentUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var commentID string
+	var cf contentFlags
+
+	for i := 0; i < len(remaining); i++ {
+		if cf.parse(remaining, &i) {
+			continue
+		}
+		if commentID == "" {
+			commentID = remaining[i]
+		}
+	}
+
+	if commentID == "" {
+		return errors.New("usage: basecamp comment-update [project_id] <comment_id> --content <html>|--content-file <file>|--stdin [--markdown]")
+	}
+
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		return errors.New("--content required")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+
+	data, err := cl.Put("/buckets/"+projectID+"/comments/"+commentID+".json", map[string]string{"content": content})
+	if err != nil {
+		return err
+	}
+
+	var updated Comment
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":  "ok",
+		"id":      updated.ID,
+		"message": "Comment updated",
+	})
+}
+
+// CommentTrashCmd moves a comment to the trash
+type CommentTrashCmd struct{}
+
+func (c *CommentTrashCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp comment-trash [project_id] <comment_id>")
+	}
+	commentID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.Put("/buckets/"+projectID+"/recordings/"+commentID+"/status/trashed.json", nil)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":     "ok",
+		"comment_id": commentID,
+		"message":    "Comment moved to trash",
 	})
 }
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+// markdownFlags holds --markdown and --no-markdown, which override the
+// markdown default from the config file
+type markdownFlags struct {
+	Markdown   bool
+	NoMarkdown bool
+}
+
+// parse handles a markdown flag and reports whether arg was one
+func (m *markdownFlags) parse(arg string) bool {
+	switch arg {
+	case "--markdown":
+		m.Markdown = true
+	case "--no-markdown":
+		m.NoMarkdown = true
+	default:
+		return false
+	}
+	return true
+}
+
+// enabled reports whether text should be converted from markdown
+func (m markdownFlags) enabled() bool {
+	if m.Markdown || m.NoMarkdown {
+		return m.Markdown
+	}
+	cfg, err := config.Load()
+	return err == nil && cfg.Markdown
+}
+
+// convert turns markdown text into Basecamp rich text when enabled
+func (m markdownFlags) convert(text string) string {
+	if text == "" || !m.enabled() {
+		return text
+	}
+	return markdownToHTML(text)
+}
+
+// contentFlags holds the flags that supply rich text: --content, a file
+// with --content-file (- for stdin), --stdin, the markdown flags,
+// --no-mentions to keep @names as typed and --mentions to expand them in
+// content read from stdin
+type contentFlags struct {
+	markdownFlags
+	Content    *string
+	File       string
+	Stdin      bool
+	Mentions   bool
+	NoMentions bool
+}
+
+// parse handles the content flag at args[*i], advancing *i past its
+// value, and reports whether it was one
+func (f *contentFlags) parse(args []string, i *int) bool {
+	value := func() string {
+		if *i+1 < len(args) {
+			*i++
+			return args[*i]
+		}
+		return ""
+	}
+
+	if f.markdownFlags.parse(args[*i]) {
+		return true
+	}
+
+	switch args[*i] {
+	case "--content":
+		v := value()
+		f.Content = &v
+	case "--content-file":
+		f.File = value()
+	case "--stdin":
+		f.Stdin = true
+	case "--mentions":
+		f.Mentions = true
+	case "--no-mentions":
+		f.NoMentions = true
+	default:
+		return false
+	}
+	return true
+}
+
+// given reports whether any content source was set
+func (f contentFlags) given() bool {
+	return f.Content != nil || f.File != "" || f.Stdin
+}
+
+// read returns the content from whichever source was given, converted
+// from markdown if enabled
+func (f contentFlags) read() (string, error) {
+	sources := 0
+	for _, set := range []bool{f.Content != nil, f.File != "", f.Stdin} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", errors.New("only one of --content, --content-file or --stdin can be given")
+	}
+
+	var content string
+	switch {
+	case f.Content != nil:
+		content = *f.Content
+	case f.Stdin || f.File == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		content = strings.TrimRight(string(data), "\n")
+	case f.File != "":
+		data, err := os.ReadFile(f.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read content file: %w", err)
+		}
+		content = strings.TrimRight(string(data), "\n")
+	}
+
+	return f.convert(content), nil
+}
+
+// expandsMentions reports whether @names should become mentions. Piped
+// content such as build logs is full of things like @types/node, so it is
+// left alone unless --mentions is given.
+func (f contentFlags) expandsMentions() bool {
+	if f.NoMentions {
+		return false
+	}
+	return f.Mentions || !(f.Stdin || f.File == "-")
+}
+
+// mentions turns @names in the content into mentions when expandsMentions
+// allows it
+func (f contentFlags) mentions(cl *client.Client, content string) (string, error) {
+	if !f.expandsMentions() || content == "" {
+		return content, nil
+	}
+	content, _, err := expandMentions(cl, content)
+	return content, err
+}
+
+// attachFlags holds the repeatable --attach <path> flag, which uploads
+// files and embeds them in rich text
+type attachFlags struct {
+	Paths []string
+}
+
+// parse handles --attach at args[*i], advancing *i past its value, and
+// reports whether it was one
+func (a *attachFlags) parse(args []string, i *int) bool {
+	if args[*i] != "--attach" {
+		return false
+	}
+	if *i+1 < len(args) {
+		*i++
+		a.Paths = append(a.Paths, args[*i])
+	}
+	return true
+}
+
+// embed uploads each attached file and appends it to the content,
+// returning the new content and the attachable sgids. Every file is
+// checked before the first upload so a typo does not leave orphans.
+func (a attachFlags) embed(cl *client.Client, content string) (string, []string, error) {
+	for _, path := range a.Paths {
+		if _, err := os.Stat(path); err != nil {
+			return "", nil, fmt.Errorf("--attach: %w", err)
+		}
+	}
+
+	var sgids []string
+	for _, path := range a.Paths {
+		sgid, err := uploadAttachment(cl, path)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", path, err)
+		}
+		sgids = append(sgids, sgid)
+		content += attachmentHTML(sgid)
+	}
+	return content, sgids, nil
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeTimeRegex = regexp.MustCompile(`^([+-])(\d+)([mhdw])$`)
+var clockTimeRegex = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseClockTime parses times of day like "14:00", "9:30am" or "2pm"
+func parseClockTime(s string) (hour, minute, second int, ok bool) {
+	m := clockTimeRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	minute, _ = strconv.Atoi(m[2])
+	second, _ = strconv.Atoi(m[3])
+
+	switch m[4] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	default:
+		// A bare number is ambiguous with other tokens, so require a colon
+		if m[2] == "" {
+			return 0, 0, 0, false
+		}
+	}
+
+	if hour > 23 || minute > 59 || second > 59 {
+		return 0, 0, 0, false
+	}
+	return hour, minute, second, true
+}
+
+// parseNaturalTime parses absolute and relative times such as
+// "2026-03-01T14:00:00Z", "2026-03-01 14:00", "tomorrow 14:00", "friday 9am",
+// "next monday", "+2d" or "+90m". Times without a zone are in now's location.
+func parseNaturalTime(s string, now time.Time) (time.Time, error) {
+	input := strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+
+	loc := now.Location()
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	invalid := fmt.Errorf("can't understand date '%s' (try 2026-03-01 14:00, tomorrow 9am, friday, +2d or +90m)", s)
+
+	year, month, day := now.Date()
+	hour, minute, second := 0, 0, 0
+	rest := fields[1:]
+
+	switch first := fields[0]; {
+	case first == "now":
+		if len(rest) > 0 {
+			return time.Time{}, invalid
+		}
+		return now, nil
+
+	case relativeTimeRegex.MatchString(first):
+		m := relativeTimeRegex.FindStringSubmatch(first)
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+
+		switch m[3] {
+		case "m", "h":
+			if len(rest) > 0 {
+				return time.Time{}, invalid
+			}
+			unit := time.Minute
+			if m[3] == "h" {
+				unit = time.Hour
+			}
+			return now.Add(time.Duration(n) * unit), nil
+		case "w":
+			n *= 7
+		}
+
+		// Day offsets keep the current time of day unless one is given
+		year, month, day = now.AddDate(0, 0, n).Date()
+		hour, minute, second = now.Clock()
+
+	case first == "today":
+	case first == "tomorrow":
+		year, month, day = now.AddDate(0, 0, 1).Date()
+	case first == "yesterday":
+		year, month, day = now.AddDate(0, 0, -1).Date()
+
+	default:
+		if first == "next" && len(rest) > 0 {
+			first, rest = rest[0], rest[1:]
+		}
+
+		if weekday, ok := weekdayNames[first]; ok {
+			// Always the next such day, never today
+			days := (int(weekday)-int(now.Weekday())+6)%7 + 1
+			year, month, day = now.AddDate(0, 0, days).Date()
+		} else if d, err := time.ParseInLocation("2006-01-02", first, loc); err == nil {
+			year, month, day = d.Date()
+		} else if _, _, _, ok := parseClockTime(strings.Join(fields, "")); ok {
+			// Only a time, so today
+			rest = fields
+		} else {
+			return time.Time{}, invalid
+		}
+	}
+
+	if len(rest) > 0 && rest[0] == "at" {
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		var ok bool
+		if hour, minute, second, ok = parseClockTime(strings.Join(rest, "")); !ok {
+			return time.Time{}, invalid
+		}
+	}
+
+	return time.Date(year, month, day, hour, minute, second, 0, loc), nil
+}
+
+// parseWindowTime is parseNaturalTime for the ends of a date range, where
+// a bare weekday name means that day of the current week (Monday to
+// Sunday), so "--from monday --to friday" is this week
+func parseWindowTime(s string, now time.Time) (time.Time, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) > 0 {
+		if weekday, ok := weekdayNames[fields[0]]; ok {
+			monday := now.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+			target := monday.AddDate(0, 0, (int(weekday)+6)%7)
+			return parseNaturalTime(strings.Join(append([]string{target.Format("2006-01-02")}, fields[1:]...), " "), now)
+		}
+	}
+	return parseNaturalTime(s, now)
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNaturalTime(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	// A Wednesday afternoon
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, loc)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "2026-03-10T09:00:00Z", want: "2026-03-10T09:00:00Z"},
+		{in: "2026-03-10T09:00", want: "2026-03-10T09:00:00-05:00"},
+		{in: "2026-03-10", want: "2026-03-10T00:00:00-05:00"},
+		{in: "2026-03-10 14:00", want: "2026-03-10T14:00:00-05:00"},
+		{in: "now", want: "2026-03-04T15:30:00-05:00"},
+		{in: "today", want: "2026-03-04T00:00:00-05:00"},
+		{in: "Tomorrow 14:00", want: "2026-03-05T14:00:00-05:00"},
+		{in: "tomorrow at 9am", want: "2026-03-05T09:00:00-05:00"},
+		{in: "yesterday 12pm", want: "2026-03-03T12:00:00-05:00"},
+		{in: "14:15", want: "2026-03-04T14:15:00-05:00"},
+		{in: "2:30 pm", want: "2026-03-04T14:30:00-05:00"},
+		{in: "12am", want: "2026-03-04T00:00:00-05:00"},
+		{in: "friday", want: "2026-03-06T00:00:00-05:00"},
+		{in: "wed 10:00", want: "2026-03-11T10:00:00-05:00"},
+		{in: "next monday 9am", want: "2026-03-09T09:00:00-05:00"},
+		{in: "+2d", want: "2026-03-06T15:30:00-05:00"},
+		{in: "+1w 10:00", want: "2026-03-11T10:00:00-05:00"},
+		{in: "-1d", want: "2026-03-03T15:30:00-05:00"},
+		{in: "+90m", want: "2026-03-04T17:00:00-05:00"},
+		{in: "+2h", want: "2026-03-04T17:30:00-05:00"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseNaturalTime(tt.in, now)
+		if err != nil {
+			t.Errorf("parseNaturalTime(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got.Format(time.RFC3339) != tt.want {
+			t.Errorf("parseNaturalTime(%q) = %s, want %s", tt.in, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestParseNaturalTimeErrors(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+
+	for _, in := range []string{"", "soon", "tomorrow 25:00", "13pm", "+2h 10:00", "now 10:00", "2026-02-30", "14"} {
+		if _, err := parseNaturalTime(in, now); err == nil {
+			t.Errorf("parseNaturalTime(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseWindowTime(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "monday", want: "2026-03-02T00:00:00Z"},
+		{in: "Friday 17:00", want: "2026-03-06T17:00:00Z"},
+		{in: "sunday", want: "2026-03-08T00:00:00Z"},
+		{in: "next monday", want: "2026-03-09T00:00:00Z"},
+		{in: "+7d", want: "2026-03-11T15:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWindowTime(tt.in, now)
+		if err != nil {
+			t.Errorf("parseWindowTime(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got.Format(time.RFC3339) != tt.want {
+			t.Errorf("parseWindowTime(%q) = %s, want %s", tt.in, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
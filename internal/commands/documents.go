@@ -37,9 +37,15 @@ func fetchVault(cl *client.Client, projectID string) (ProjectDetail, Vault, erro
 type DocsCmd struct{}
 
 type Vault struct {
-	ID           int    `json:"id"`
-	Title        string `json:"title"`
-	DocumentsURL string `json:"documents_url"`
+	ID             int    `json:"id"`
+	Title          string `json:"title"`
+	DocumentsCount int    `json:"documents_count"`
+	DocumentsURL   string `json:"documents_url"`
+	UploadsCount   int    `json:"uploads_count"`
+	UploadsURL     string `json:"uploads_url"`
+	VaultsCount    int    `json:"vaults_count"`
+	VaultsURL      string `json:"vaults_url"`
+	AppURL         string `json:"app_url"`
 }
 
 type Document struct {
@@ -135,12 +141,23 @@ func (c *DocCmd) Run(args []string) error {
 	// Parse doc_id and flags
 	var docID string
 	showComments := false
+	body := bodyMarkdown
 
 	for i := 0; i < len(remaining); i++ {
-		if remaining[i] == "--comments" {
+		switch remaining[i] {
+		case "--comments":
 			showComments = true
-		} else if docID == "" {
-			docID = remaining[i]
+		case "--body":
+			if i+1 < len(remaining) {
+				if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+					return err
+				}
+				i++
+			}
+		default:
+			if docID == "" {
+				docID = remaining[i]
+			}
 		}
 	}
 
@@ -167,7 +184,7 @@ func (c *DocCmd) Run(args []string) error {
 	output := DocDetailOutput{
 		ID:            doc.ID,
 		Title:         doc.Title,
-		Content:       stripHTML(doc.Content),
+		Content:       renderBody(body, doc.Content),
 		Creator:       doc.Creator.Name,
 		CreatedAt:     doc.CreatedAt,
 		UpdatedAt:     doc.UpdatedAt,
@@ -176,7 +193,7 @@ func (c *DocCmd) Run(args []string) error {
 	}
 
 	if showComments && doc.CommentsURL != "" {
-		comments, err := fetchComments(cl, doc.CommentsURL)
+		comments, err := fetchComments(cl, doc.CommentsURL, body)
 		if err != nil {
 			return err
 		}
@@ -189,10 +206,11 @@ func (c *DocCmd) Run(args []string) error {
 type DocCreateCmd struct{}
 
 type DocCreateOutput struct {
-	Status  string `json:"status"`
-	ID      int    `json:"id"`
-	Title   string `json:"title"`
-	Message string `json:"message"`
+	Status          string   `json:"status"`
+	ID              int      `json:"id"`
+	Title           string   `json:"title"`
+	AttachableSGIDs []string `json:"attachable_sgids,omitempty"`
+	Message         string   `json:"message"`
 }
 
 func (c *DocCreateCmd) Run(args []string) error {
@@ -202,7 +220,9 @@ func (c *DocCreateCmd) Run(args []string) error {
 	}
 
 	// Parse flags
-	var title, content string
+	var title string
+	var cf contentFlags
+	var af attachFlags
 
 	for i := 0; i < len(remaining); i++ {
 		switch remaining[i] {
@@ -211,10 +231,9 @@ func (c *DocCreateCmd) Run(args []string) error {
 				title = remaining[i+1]
 				i++
 			}
-		case "--content":
-			if i+1 < len(remaining) {
-				content = remaining[i+1]
-				i++
+		default:
+			if !cf.parse(remaining, &i) {
+				af.parse(remaining, &i)
 			}
 		}
 	}
@@ -222,17 +241,30 @@ func (c *DocCreateCmd) Run(args []string) error {
 	if title == "" {
 		return errors.New("--title required")
 	}
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
 
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+
 	_, vault, err := fetchVault(cl, projectID)
 	if err != nil {
 		return err
 	}
 
+	content, sgids, err := af.embed(cl, content)
+	if err != nil {
+		return err
+	}
+
 	// Create document
 	payload := map[string]any{
 		"title":  title,
@@ -260,9 +292,10 @@ func (c *DocCreateCmd) Run(args []string) error {
 	}
 
 	return PrintJSON(DocCreateOutput{
-		Status:  "ok",
-		ID:      created.ID,
-		Title:   created.Title,
-		Message: fmt.Sprintf("Document '%s' created", created.Title),
+		Status:          "ok",
+		ID:              created.ID,
+		Title:           created.Title,
+		AttachableSGIDs: sgids,
+		Message:         fmt.Sprintf("Document '%s' created", created.Title),
 	})
 }
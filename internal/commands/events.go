@@ -10,11 +10,12 @@ import (
 
 // Event represents a Basecamp activity event
 type Event struct {
-	ID            int     `json:"id"`
-	Action        string  `json:"action"`
-	CreatedAt     string  `json:"created_at"`
-	RecordingType string  `json:"recording_type"`
-	Creator       Creator `json:"creator"`
+	ID            int             `json:"id"`
+	Action        string          `json:"action"`
+	CreatedAt     string          `json:"created_at"`
+	RecordingType string          `json:"recording_type"`
+	Creator       Creator         `json:"creator"`
+	Details       json.RawMessage `json:"details"`
 	Recording     struct {
 		ID    int    `json:"id"`
 		Title string `json:"title"`
@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -31,8 +32,9 @@ func fetchProject(cl *client.Client, projectID string) (ProjectDetail, error) {
 	return project, nil
 }
 
-// fetchComments fetches and parses comments from a comments URL
-func fetchComments(cl *client.Client, commentsURL string) ([]CommentOutput, error) {
+// fetchComments fetches and parses comments from a comments URL, rendering
+// their content in the given body format
+func fetchComments(cl *client.Client, commentsURL, body string) ([]CommentOutput, error) {
 	commentsData, err := cl.GetAll(commentsURL)
 	if err != nil {
 		return nil, err
@@ -44,16 +46,44 @@ func fetchComments(cl *client.Client, commentsURL string) ([]CommentOutput, erro
 		if err := json.Unmarshal(commentJSON, &comment); err != nil {
 			return nil, err
 		}
-		author := "Unknown"
-		if comment.Creator.Name != "" {
-			author = comment.Creator.Name
-		}
-		comments[i] = CommentOutput{
-			ID:        comment.ID,
-			Author:    author,
-			Content:   stripHTML(comment.Content),
-			CreatedAt: comment.CreatedAt,
-		}
+		comments[i] = commentToOutput(comment, body)
 	}
 	return comments, nil
 }
+
+// forEachConcurrently calls fn for each index in [0, n) using at most
+// workers goroutines, and returns once every call has finished
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// fetchMyProfile gets the authenticated user's profile
+func fetchMyProfile(cl *client.Client) (Person, error) {
+	data, err := cl.Get("/my/profile.json")
+	if err != nil {
+		return Person{}, err
+	}
+
+	var person Person
+	if err := json.Unmarshal(data, &person); err != nil {
+		return Person{}, err
+	}
+	return person, nil
+}
@@ -0,0 +1,375 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Body formats for rich text in command output
+const (
+	bodyMarkdown = "markdown"
+	bodyText     = "text"
+	bodyHTML     = "html"
+)
+
+// parseBodyFormat validates the value of --body
+func parseBodyFormat(value string) (string, error) {
+	switch value {
+	case bodyMarkdown, bodyText, bodyHTML:
+		return value, nil
+	}
+	return "", errors.New("--body must be markdown, text or html")
+}
+
+// renderBody formats rich text for output: markdown keeps structure,
+// text flattens it to one line and html returns it unchanged
+func renderBody(format, s string) string {
+	switch format {
+	case bodyText:
+		return stripHTML(s)
+	case bodyHTML:
+		return s
+	}
+	return htmlToMarkdown(s)
+}
+
+// htmlNode is an element, or a text node when tag is empty
+type htmlNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+}
+
+var (
+	htmlTokenRegex = regexp.MustCompile(`(?s)<!--.*?-->|<(/?)([a-zA-Z][\w-]*)((?:[^>"']|"[^"]*"|'[^']*')*?)(/?)>`)
+	htmlAttrRegex  = regexp.MustCompile(`([\w:-]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+	htmlVoidTags   = map[string]bool{"br": true, "img": true, "hr": true, "input": true, "meta": true, "link": true, "source": true}
+)
+
+// parseHTML builds a tree from rich text. It is lenient the way browsers
+// are: unknown closing tags are ignored and open elements are closed by
+// the first closing tag of an enclosing element.
+func parseHTML(s string) *htmlNode {
+	root := &htmlNode{tag: "root"}
+	stack := []*htmlNode{root}
+	top := func() *htmlNode { return stack[len(stack)-1] }
+	addText := func(text string) {
+		if text != "" {
+			top().children = append(top().children, &htmlNode{text: html.UnescapeString(text)})
+		}
+	}
+
+	last := 0
+	for _, m := range htmlTokenRegex.FindAllStringSubmatchIndex(s, -1) {
+		addText(s[last:m[0]])
+		last = m[1]
+		if m[4] < 0 {
+			continue // comment
+		}
+
+		tag := strings.ToLower(s[m[4]:m[5]])
+		if s[m[2]:m[3]] == "/" {
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].tag == tag {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		node := &htmlNode{tag: tag, attrs: map[string]string{}}
+		for _, a := range htmlAttrRegex.FindAllStringSubmatch(s[m[6]:m[7]], -1) {
+			node.attrs[strings.ToLower(a[1])] = html.UnescapeString(a[2] + a[3] + a[4])
+		}
+		top().children = append(top().children, node)
+		if !htmlVoidTags[tag] && s[m[8]:m[9]] != "/" {
+			stack = append(stack, node)
+		}
+	}
+	addText(s[last:])
+	return root
+}
+
+// textContent returns the text inside a node
+func (n *htmlNode) textContent() string {
+	switch n.tag {
+	case "":
+		return n.text
+	case "br":
+		return "\n"
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(c.textContent())
+	}
+	return b.String()
+}
+
+// find returns the first descendant with the given tag
+func (n *htmlNode) find(tag string) *htmlNode {
+	for _, c := range n.children {
+		if c.tag == tag {
+			return c
+		}
+		if found := c.find(tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+var htmlBlockTags = map[string]bool{
+	"div": true, "p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "blockquote": true, "pre": true, "hr": true,
+	"figure": true, "section": true, "article": true, "table": true, "tr": true,
+}
+
+// isBlock reports whether a node starts its own block
+func (n *htmlNode) isBlock() bool {
+	return htmlBlockTags[n.tag]
+}
+
+// markdownRenderer collects image and file attachments as numbered
+// references listed after the body
+type markdownRenderer struct {
+	references []string
+}
+
+// htmlToMarkdown renders Basecamp rich text as markdown. Mentions become
+// @Name, and images and file attachments become reference links.
+func htmlToMarkdown(s string) string {
+	if s == "" {
+		return ""
+	}
+	r := &markdownRenderer{}
+	body := strings.Join(r.blocks(parseHTML(s).children), "\n\n")
+	if len(r.references) > 0 {
+		body = strings.TrimSpace(body + "\n\n" + strings.Join(r.references, "\n"))
+	}
+	return body
+}
+
+// blocks renders nodes as markdown blocks, grouping runs of inline
+// nodes into paragraphs
+func (r *markdownRenderer) blocks(nodes []*htmlNode) []string {
+	var blocks []string
+	var inline []*htmlNode
+	flush := func() {
+		if text := cleanMarkdownLines(r.inline(inline)); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline = nil
+	}
+
+	for _, n := range nodes {
+		if !n.isBlock() {
+			inline = append(inline, n)
+			continue
+		}
+		flush()
+		if block := r.block(n); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func (r *markdownRenderer) block(n *htmlNode) string {
+	switch n.tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := strings.ReplaceAll(cleanMarkdownLines(r.inline(n.children)), "\n", " ")
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", int(n.tag[1]-'0')) + " " + text
+
+	case "blockquote":
+		body := strings.Join(r.blocks(n.children), "\n\n")
+		if body == "" {
+			return ""
+		}
+		lines := strings.Split(body, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+
+	case "pre":
+		code := strings.Trim(n.textContent(), "\n")
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return fence + "\n" + code + "\n" + fence
+
+	case "ul", "ol":
+		return r.list(n)
+
+	case "hr":
+		return "---"
+	}
+
+	// div, p, li outside a list and other containers
+	return strings.Join(r.blocks(n.children), "\n\n")
+}
+
+// list renders list items, indenting continuation lines and nested
+// lists under their item's marker
+func (r *markdownRenderer) list(n *htmlNode) string {
+	var items []string
+	number := 1
+	for _, c := range n.children {
+		if c.tag != "li" {
+			continue
+		}
+		marker := "- "
+		if n.tag == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+
+		lines := strings.Split(strings.Join(r.blocks(c.children), "\n"), "\n")
+		for i := range lines {
+			if i == 0 {
+				lines[i] = marker + lines[i]
+			} else if lines[i] != "" {
+				lines[i] = strings.Repeat(" ", len(marker)) + lines[i]
+			}
+		}
+		items = append(items, strings.TrimRight(strings.Join(lines, "\n"), " "))
+	}
+	return strings.Join(items, "\n")
+}
+
+// inline renders inline nodes; newlines only come from <br>
+func (r *markdownRenderer) inline(nodes []*htmlNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.tag {
+		case "":
+			b.WriteString(escapeMarkdown(whitespaceRegex.ReplaceAllString(n.text, " ")))
+		case "br":
+			b.WriteString("\n")
+		case "strong", "b":
+			b.WriteString(wrapMarkdown("**", r.inline(n.children)))
+		case "em", "i":
+			b.WriteString(wrapMarkdown("*", r.inline(n.children)))
+		case "del", "s", "strike":
+			b.WriteString(wrapMarkdown("~~", r.inline(n.children)))
+		case "code":
+			b.WriteString(wrapMarkdown("`", n.textContent()))
+		case "a":
+			b.WriteString(r.link(n))
+		case "img":
+			b.WriteString(r.reference("!", coalesce(n.attrs["alt"], "image"), n.attrs["src"]))
+		case "bc-attachment":
+			b.WriteString(r.attachment(n))
+		default:
+			if n.isBlock() {
+				// A block inside inline content, such as a list in a link
+				b.WriteString("\n" + strings.Join(r.blocks([]*htmlNode{n}), "\n") + "\n")
+			} else {
+				b.WriteString(r.inline(n.children))
+			}
+		}
+	}
+	return b.String()
+}
+
+func (r *markdownRenderer) link(n *htmlNode) string {
+	href := n.attrs["href"]
+	text := strings.TrimSpace(r.inline(n.children))
+	switch {
+	case href == "":
+		return text
+	case text == "" || text == escapeMarkdown(href):
+		return "<" + href + ">"
+	}
+	return "[" + text + "](" + href + ")"
+}
+
+// attachment renders a <bc-attachment>: people are mentions, images and
+// files are references and anything else falls back to its caption
+func (r *markdownRenderer) attachment(n *htmlNode) string {
+	contentType := n.attrs["content-type"]
+	caption := strings.TrimSpace(coalesce(n.attrs["caption"], n.attrs["filename"]))
+	if figcaption := n.find("figcaption"); figcaption != nil && caption == "" {
+		caption = strings.TrimSpace(figcaption.textContent())
+	}
+
+	switch {
+	case contentType == "application/vnd.basecamp.mention":
+		name := caption
+		if name == "" {
+			name = strings.TrimSpace(n.textContent())
+		}
+		return "@" + escapeMarkdown(name)
+	case strings.HasPrefix(contentType, "image/"):
+		return r.reference("!", coalesce(caption, "image"), coalesce(n.attrs["url"], n.attrs["href"]))
+	case n.attrs["href"] != "" || n.attrs["url"] != "":
+		return r.reference("", coalesce(caption, "attachment"), coalesce(n.attrs["href"], n.attrs["url"]))
+	}
+	return escapeMarkdown(caption)
+}
+
+// reference adds a numbered reference and returns the link to it
+func (r *markdownRenderer) reference(prefix, text, url string) string {
+	if url == "" {
+		return escapeMarkdown(text)
+	}
+	r.references = append(r.references, fmt.Sprintf("[%d]: %s", len(r.references)+1, url))
+	return fmt.Sprintf("%s[%s][%d]", prefix, escapeMarkdown(text), len(r.references))
+}
+
+// wrapMarkdown surrounds text with a marker, keeping surrounding spaces
+// outside it so the markdown stays valid
+func wrapMarkdown(marker, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := text[:strings.Index(text, trimmed)]
+	end := text[len(start)+len(trimmed):]
+	return start + marker + trimmed + marker + end
+}
+
+// escapeMarkdown escapes characters that would otherwise format text.
+// Underscores inside words are left alone.
+func escapeMarkdown(s string) string {
+	isWord := func(i int) bool {
+		if i < 0 || i >= len(s) {
+			return false
+		}
+		c := s[i]
+		return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case strings.IndexByte("\\`*[]~", c) >= 0:
+			b.WriteByte('\\')
+		case c == '_' && !(isWord(i-1) && isWord(i+1)):
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// cleanMarkdownLines trims each line and drops blank lines at the ends,
+// keeping at most one blank line in a row
+func cleanMarkdownLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text := blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.Trim(text, "\n")
+}
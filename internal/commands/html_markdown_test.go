@@ -0,0 +1,94 @@
+package commands
+
+import "testing"
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"empty", "", ""},
+		{"plain div", "<div>Hello</div>", "Hello"},
+		{"breaks", "<div>One<br>Two<br><br>Three</div>", "One\nTwo\n\nThree"},
+		{"paragraphs", "<p>One</p>\n<p>Two</p>", "One\n\nTwo"},
+		{"formatting", "<div><strong>b</strong> <em>i</em> <del>d</del> <code>c</code></div>", "**b** *i* ~~d~~ `c`"},
+		{"space inside tags", "<div>a<strong> b </strong>c</div>", "a **b** c"},
+		{"link", `<div><a href="https://x.com/?a=1&amp;b=2">docs</a></div>`, "[docs](https://x.com/?a=1&b=2)"},
+		{"bare link", `<a href="https://x.com">https://x.com</a>`, "<https://x.com>"},
+		{"entities", "<div>tom &amp; jerry &lt;3</div>", "tom & jerry <3"},
+		{"escapes", "<div>a * b, [x] and _y_ in snake_case</div>", `a \* b, \[x\] and \_y\_ in snake_case`},
+		{"heading", "<h1>Title</h1><div>Body</div>", "# Title\n\nBody"},
+		{"bullet list", "<ul><li>one</li><li>two</li></ul>", "- one\n- two"},
+		{"numbered list", "<ol><li>one</li><li>two</li></ol>", "1. one\n2. two"},
+		{"nested list", "<ul><li>a<ul><li>b<ol><li>c</li></ol></li></ul></li><li>d</li></ul>", "- a\n  - b\n    1. c\n- d"},
+		{"list after text", "<div>Intro</div><ul>\n<li>x</li>\n</ul>", "Intro\n\n- x"},
+		{"quote", "<blockquote>quoted <em>text</em><br>two</blockquote>", "> quoted *text*\n> two"},
+		{"code block", "<pre>x := 1\n<b>y</b> &lt; 2</pre>", "```\nx := 1\ny < 2\n```"},
+		{"code block with fence", "<pre>```</pre>", "````\n```\n````"},
+		{"unclosed tags", "<div><strong>bold</div><div>next", "**bold**\n\nnext"},
+		{"comment ignored", "<div><!-- note -->text</div>", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToMarkdown(tt.html); got != tt.want {
+				t.Errorf("htmlToMarkdown(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLToMarkdownAttachments(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"mention",
+			`<div>Hi <bc-attachment sgid="abc" content-type="application/vnd.basecamp.mention"><figure><img src="https://a/avatar.png"><figcaption>Jane Doe</figcaption></figure></bc-attachment>, please review</div>`,
+			"Hi @Jane Doe, please review",
+		},
+		{
+			"image",
+			`<bc-attachment sgid="x" content-type="image/png" url="https://bc/img.png" filename="shot.png" caption="Screenshot"><figure><img src="https://bc/img.png"></figure></bc-attachment>`,
+			"![Screenshot][1]\n\n[1]: https://bc/img.png",
+		},
+		{
+			"file and image",
+			`<div><bc-attachment content-type="application/pdf" href="https://bc/spec.pdf" filename="spec.pdf"></bc-attachment></div><div><img src="https://x/y.png" alt="chart"></div>`,
+			"[spec.pdf][1]\n\n![chart][2]\n\n[1]: https://bc/spec.pdf\n[2]: https://x/y.png",
+		},
+		{
+			"unknown attachment",
+			`<bc-attachment content-type="application/vnd.basecamp.thing" caption="Thing"></bc-attachment>`,
+			"Thing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToMarkdown(tt.html); got != tt.want {
+				t.Errorf("htmlToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderBody(t *testing.T) {
+	content := "<div>One</div><ul><li>two</li></ul>"
+
+	if got := renderBody(bodyMarkdown, content); got != "One\n\n- two" {
+		t.Errorf("markdown = %q", got)
+	}
+	if got := renderBody(bodyText, content); got != "One two" {
+		t.Errorf("text = %q", got)
+	}
+	if got := renderBody(bodyHTML, content); got != content {
+		t.Errorf("html = %q", got)
+	}
+	if _, err := parseBodyFormat("rtf"); err == nil {
+		t.Error("expected error for unknown body format")
+	}
+}
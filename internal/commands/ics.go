@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icsDateFormat = "20060102"
+const icsTimestampFormat = "20060102T150405Z"
+
+// icsEscape escapes text for use in an iCalendar property value
+func icsEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, ";", "\\;")
+	s = strings.ReplaceAll(s, ",", "\\,")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "\\n")
+}
+
+// icsDate converts a YYYY-MM-DD date to the iCalendar DATE form
+func icsDate(date string) string {
+	return strings.ReplaceAll(date, "-", "")
+}
+
+// icsTimestamp formats an RFC3339 timestamp as a UTC iCalendar DATE-TIME,
+// falling back to fallback if it cannot be parsed
+func icsTimestamp(timestamp string, fallback time.Time) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		t = fallback
+	}
+	return t.UTC().Format(icsTimestampFormat)
+}
+
+// writeICSLine writes a content line, folding it at 75 octets as
+// required by RFC 5545
+func writeICSLine(b *strings.Builder, line string) {
+	// Continuation lines start with a space, which counts toward the limit
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// icsProperty is a single unfolded content line such as
+// DTSTART;TZID=Europe/Paris:20260102T090000
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icsComponent is a VEVENT, VTODO or other block with its own properties.
+// Properties of nested components like VALARM are not included.
+type icsComponent struct {
+	Name       string
+	Properties []icsProperty
+}
+
+func (c icsComponent) property(name string) (icsProperty, bool) {
+	for _, p := range c.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return icsProperty{}, false
+}
+
+// icsUnescape reverses icsEscape
+func icsUnescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// parseICSProperty splits a content line into its name, parameters and
+// value. Colons and semicolons inside quoted parameter values are kept.
+func parseICSProperty(line string) (icsProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i := 0; i < len(line) && colon == -1; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+	}
+	if colon == -1 {
+		return icsProperty{}, fmt.Errorf("invalid content line '%s'", line)
+	}
+
+	p := icsProperty{Params: map[string]string{}, Value: line[colon+1:]}
+	var parts []string
+	start := 0
+	inQuotes = false
+	for i := 0; i < colon; i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				parts = append(parts, line[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, line[start:colon])
+
+	p.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		p.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return p, nil
+}
+
+// parseICSComponents returns the components named name, e.g. VEVENT, from
+// an iCalendar file
+func parseICSComponents(text, name string) ([]icsComponent, error) {
+	// Unfold continuation lines first
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\n ", "")
+	text = strings.ReplaceAll(text, "\n\t", "")
+
+	var components []icsComponent
+	var current *icsComponent
+	depth := 0
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		p, err := parseICSProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		value := strings.ToUpper(strings.TrimSpace(p.Value))
+
+		switch {
+		case p.Name == "BEGIN" && current == nil && value == name:
+			current = &icsComponent{Name: value}
+		case p.Name == "BEGIN" && current != nil:
+			depth++
+		case p.Name == "END" && current != nil && depth > 0:
+			depth--
+		case p.Name == "END" && current != nil:
+			components = append(components, *current)
+			current = nil
+		case current != nil && depth == 0:
+			current.Properties = append(current.Properties, p)
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("unterminated %s", name)
+	}
+	return components, nil
+}
+
+// parseICSTime parses a DATE or DATE-TIME property. UTC times keep UTC,
+// TZID times use that zone and floating times use loc. The second result
+// is true for a DATE value.
+func parseICSTime(p icsProperty, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(p.Value)
+
+	if p.Params["VALUE"] == "DATE" || len(value) == len(icsDateFormat) {
+		t, err := time.ParseInLocation(icsDateFormat, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s '%s'", p.Name, value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icsTimestampFormat, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s '%s'", p.Name, value)
+		}
+		return t, false, nil
+	}
+
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s '%s'", p.Name, value)
+	}
+	return t, false, nil
+}
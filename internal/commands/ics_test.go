@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICSEscape(t *testing.T) {
+	got := icsEscape("a, b; c\\d\nnext")
+	want := `a\, b\; c\\d\nnext`
+	if got != want {
+		t.Errorf("icsEscape() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteICSLineFolds(t *testing.T) {
+	var b strings.Builder
+	line := "SUMMARY:" + strings.Repeat("é", 100)
+	writeICSLine(&b, line)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected the line to be folded, got %d lines", len(lines))
+	}
+
+	var unfolded strings.Builder
+	for i, l := range lines {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d octets, want at most 75", i, len(l))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Errorf("continuation line %d does not start with a space", i)
+			}
+			l = l[1:]
+		}
+		unfolded.WriteString(l)
+	}
+	if unfolded.String() != line {
+		t.Error("unfolding did not restore the original line")
+	}
+}
+
+func TestICSTimestamp(t *testing.T) {
+	fallback := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := icsTimestamp("2026-03-04T10:20:30+02:00", fallback); got != "20260304T082030Z" {
+		t.Errorf("icsTimestamp() = %q", got)
+	}
+	if got := icsTimestamp("", fallback); got != "20260101T000000Z" {
+		t.Errorf("icsTimestamp() fallback = %q", got)
+	}
+}
+
+func TestParseICSComponents(t *testing.T) {
+	text := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:one\r\n" +
+		"SUMMARY:Long\r\n  summary\r\n" +
+		"DTSTART;TZID=\"Europe/Paris\":20260102T090000\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"SUMMARY:Alarm\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:two\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	components, err := parseICSComponents(text, "VEVENT")
+	if err != nil {
+		t.Fatalf("parseICSComponents() error: %v", err)
+	}
+	if len(components) != 1 {
+		t.Fatalf("got %d components, want 1", len(components))
+	}
+
+	summary, _ := components[0].property("SUMMARY")
+	if summary.Value != "Long summary" {
+		t.Errorf("SUMMARY = %q, want unfolded %q", summary.Value, "Long summary")
+	}
+	start, _ := components[0].property("DTSTART")
+	if start.Params["TZID"] != "Europe/Paris" || start.Value != "20260102T090000" {
+		t.Errorf("DTSTART = %+v", start)
+	}
+
+	if _, err := parseICSComponents("BEGIN:VEVENT\nUID:x\n", "VEVENT"); err == nil {
+		t.Error("expected an error for an unterminated VEVENT")
+	}
+}
+
+func TestICSUnescape(t *testing.T) {
+	s := "a, b; c\\d\nnext"
+	if got := icsUnescape(icsEscape(s)); got != s {
+		t.Errorf("icsUnescape(icsEscape()) = %q, want %q", got, s)
+	}
+}
+
+func TestParseICSTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	tests := []struct {
+		prop   icsProperty
+		want   time.Time
+		allDay bool
+	}{
+		{icsProperty{Name: "DTSTART", Value: "20260102T090000Z"}, time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), false},
+		{icsProperty{Name: "DTSTART", Params: map[string]string{"TZID": "Europe/Paris"}, Value: "20260102T090000"}, time.Date(2026, 1, 2, 9, 0, 0, 0, paris), false},
+		{icsProperty{Name: "DTSTART", Value: "20260102T090000"}, time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), false},
+		{icsProperty{Name: "DTSTART", Params: map[string]string{"VALUE": "DATE"}, Value: "20260102"}, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		got, allDay, err := parseICSTime(tt.prop, time.UTC)
+		if err != nil {
+			t.Errorf("parseICSTime(%q) error: %v", tt.prop.Value, err)
+			continue
+		}
+		if !got.Equal(tt.want) || allDay != tt.allDay {
+			t.Errorf("parseICSTime(%q) = %v, %v, want %v, %v", tt.prop.Value, got, allDay, tt.want, tt.allDay)
+		}
+	}
+
+	if _, _, err := parseICSTime(icsProperty{Name: "DTSTART", Value: "tomorrow"}, time.UTC); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
@@ -29,6 +29,13 @@ func (c *InitCmd) Run(args []string) error {
 		RedirectURI:  redirectURI,
 	}
 
+	// Keep user-defined settings when re-running init
+	if existing, err := config.Load(); err == nil {
+		cfg.Checklists = existing.Checklists
+		cfg.Chatbots = existing.Chatbots
+		cfg.Markdown = existing.Markdown
+	}
+
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdownToHTML converts markdown to Basecamp rich text. Basecamp only
+// keeps div, h1, br, strong, em, del, a, pre, blockquote, ul, ol and li,
+// so every heading becomes an h1. Inline code is wrapped in code, which
+// Basecamp shows as plain text, so @names in it are not taken as mentions.
+func markdownToHTML(md string) string {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	md = strings.ReplaceAll(md, "\t", "    ")
+	return renderMarkdownBlocks(strings.Split(md, "\n"))
+}
+
+var (
+	mdHeadingRegex  = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdFenceRegex    = regexp.MustCompile("^ {0,3}(```|~~~)")
+	mdQuoteRegex    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdListItemRegex = regexp.MustCompile(`^( *)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRuleRegex     = regexp.MustCompile(`^ {0,3}(?:(?:- *){3,}|(?:\* *){3,}|(?:_ *){3,})$`)
+)
+
+func renderMarkdownBlocks(lines []string) string {
+	var b strings.Builder
+	lastParagraph := false
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			i++
+			continue
+		}
+
+		paragraph := false
+		switch {
+		case mdRuleRegex.MatchString(line):
+			// Basecamp has no horizontal rule, so a rule only separates blocks
+			i++
+
+		case mdFenceRegex.MatchString(line):
+			fence := mdFenceRegex.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			b.WriteString("<pre>" + html.EscapeString(strings.Join(code, "\n")) + "</pre>")
+
+		case mdHeadingRegex.MatchString(line):
+			text := mdHeadingRegex.FindStringSubmatch(line)[1]
+			b.WriteString("<h1>" + renderMarkdownInline(text) + "</h1>")
+			i++
+
+		case mdQuoteRegex.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && mdQuoteRegex.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuoteRegex.FindStringSubmatch(lines[i])[1])
+			}
+			b.WriteString("<blockquote>" + renderMarkdownBlocks(quoted) + "</blockquote>")
+
+		case mdListItemRegex.MatchString(line):
+			var items []mdListItem
+			items, i = parseMarkdownList(lines, i)
+			for j := 0; j < len(items); {
+				j = writeMarkdownList(&b, items, j)
+			}
+
+		default:
+			var text []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsMarkdownBlock(lines[i]); i++ {
+				text = append(text, lines[i])
+			}
+			if lastParagraph {
+				// Basecamp shows adjacent divs without a gap
+				b.WriteString("<div><br></div>")
+			}
+			b.WriteString("<div>" + renderMarkdownParagraph(text) + "</div>")
+			paragraph = true
+		}
+		lastParagraph = paragraph
+	}
+
+	return b.String()
+}
+
+func startsMarkdownBlock(line string) bool {
+	return mdFenceRegex.MatchString(line) || mdHeadingRegex.MatchString(line) ||
+		mdQuoteRegex.MatchString(line) || mdListItemRegex.MatchString(line) ||
+		mdRuleRegex.MatchString(line)
+}
+
+// mdListItem is one list item; indent decides how deeply it nests
+type mdListItem struct {
+	indent  int
+	ordered bool
+	text    string
+}
+
+// parseMarkdownList reads the list starting at lines[start] and returns
+// its items and the index of the first line after it. Blank lines between
+// items are allowed, and other lines continue the item before them.
+func parseMarkdownList(lines []string, start int) ([]mdListItem, int) {
+	var items []mdListItem
+	i := start
+	for i < len(lines) {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			if i+1 < len(lines) && mdListItemRegex.MatchString(lines[i+1]) {
+				i++
+				continue
+			}
+			break
+		}
+		if m := mdListItemRegex.FindStringSubmatch(line); m != nil {
+			items = append(items, mdListItem{
+				indent:  len(m[1]),
+				ordered: m[2][0] >= '0' && m[2][0] <= '9',
+				text:    m[3],
+			})
+		} else if mdFenceRegex.MatchString(line) || mdHeadingRegex.MatchString(line) || mdRuleRegex.MatchString(line) {
+			break
+		} else {
+			items[len(items)-1].text += " " + strings.TrimSpace(line)
+		}
+		i++
+	}
+	return items, i
+}
+
+// writeMarkdownList writes the list that starts at items[start], nesting
+// more deeply indented items inside the item before them, and returns the
+// index of the first item that does not belong to it. Switching between
+// bullets and numbers at the same depth starts a new list.
+func writeMarkdownList(b *strings.Builder, items []mdListItem, start int) int {
+	first := items[start]
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+
+	b.WriteString("<" + tag + ">")
+	i := start
+	for i < len(items) && items[i].indent >= first.indent && items[i].ordered == first.ordered {
+		b.WriteString("<li>" + renderMarkdownInline(items[i].text))
+		i++
+		for i < len(items) && items[i].indent > first.indent {
+			i = writeMarkdownList(b, items, i)
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</" + tag + ">")
+	return i
+}
+
+// renderMarkdownParagraph joins soft-wrapped lines with spaces and keeps
+// hard breaks (two trailing spaces or a backslash) as <br>
+func renderMarkdownParagraph(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\")
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimRight(line, " "), "\\"))
+		b.WriteString(renderMarkdownInline(line))
+		if i < len(lines)-1 {
+			if hardBreak {
+				b.WriteString("<br>")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+	return b.String()
+}
+
+var (
+	mdEscapeRegex   = regexp.MustCompile(`\\([!-/:-@\[-` + "`" + `{-~])`)
+	mdCodeRegex     = regexp.MustCompile("``(.+?)``|`([^`]+)`")
+	mdLinkRegex     = regexp.MustCompile(`!?\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdAutolinkRegex = regexp.MustCompile(`<((?:https?://|mailto:)[^>\s]+)>`)
+	mdStrongEmRegex = regexp.MustCompile(`\*\*\*(\S(?:.*?\S)?)\*\*\*`)
+	mdBoldRegex     = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicRegex   = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*|(^|[^\w])_(\S(?:[^_]*?\S)?)_($|[^\w])`)
+	mdStrikeRegex   = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mdTokenRegex    = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// renderMarkdownInline formats emphasis, links and code in one line of
+// text. Code and links are set aside first so their contents are not
+// formatted.
+func renderMarkdownInline(text string) string {
+	var r mdInlineRenderer
+	return r.restore(r.format(text))
+}
+
+// mdInlineRenderer holds the pieces set aside while formatting a line. Link
+// text is formatted with the same renderer, so code and escapes inside it
+// share one table.
+type mdInlineRenderer struct {
+	tokens []string
+}
+
+// token sets s aside and returns a placeholder for it
+func (r *mdInlineRenderer) token(s string) string {
+	r.tokens = append(r.tokens, s)
+	return fmt.Sprintf("\x00%d\x00", len(r.tokens)-1)
+}
+
+// format renders text, leaving placeholders for restore
+func (r *mdInlineRenderer) format(text string) string {
+	text = mdCodeRegex.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdCodeRegex.FindStringSubmatch(m)
+		return r.token("<code>" + html.EscapeString(strings.TrimSpace(parts[1]+parts[2])) + "</code>")
+	})
+	text = mdEscapeRegex.ReplaceAllStringFunc(text, func(m string) string {
+		return r.token(html.EscapeString(m[1:]))
+	})
+	text = mdLinkRegex.ReplaceAllStringFunc(text, func(m string) string {
+		parts := mdLinkRegex.FindStringSubmatch(m)
+		return r.token(`<a href="` + html.EscapeString(parts[2]) + `">` + r.format(parts[1]) + `</a>`)
+	})
+	text = mdAutolinkRegex.ReplaceAllStringFunc(text, func(m string) string {
+		url := html.EscapeString(mdAutolinkRegex.FindStringSubmatch(m)[1])
+		return r.token(`<a href="` + url + `">` + url + `</a>`)
+	})
+
+	text = html.EscapeString(text)
+	text = mdStrongEmRegex.ReplaceAllString(text, "<strong><em>$1</em></strong>")
+	text = mdBoldRegex.ReplaceAllString(text, "<strong>$1$2</strong>")
+	// Adjacent _words_ share the character between them, so repeat
+	for {
+		next := mdItalicRegex.ReplaceAllString(text, "$2<em>$1$3</em>$4")
+		if next == text {
+			break
+		}
+		text = next
+	}
+	return mdStrikeRegex.ReplaceAllString(text, "<del>$1</del>")
+}
+
+// restore puts back what format set aside, including placeholders nested
+// in link text
+func (r *mdInlineRenderer) restore(text string) string {
+	return mdTokenRegex.ReplaceAllStringFunc(text, func(m string) string {
+		n, _ := strconv.Atoi(mdTokenRegex.FindStringSubmatch(m)[1])
+		return r.restore(r.tokens[n])
+	})
+}
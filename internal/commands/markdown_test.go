@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"plain text", "Hello", "<div>Hello</div>"},
+		{"escapes html", "a < b & c", "<div>a &lt; b &amp; c</div>"},
+		{"paragraphs", "One\n\nTwo", "<div>One</div><div><br></div><div>Two</div>"},
+		{"soft wrap", "One\nTwo", "<div>One Two</div>"},
+		{"hard break", "One  \nTwo", "<div>One<br>Two</div>"},
+		{"heading", "## Title", "<h1>Title</h1>"},
+		{"bold and italic", "**bold** and *it* and _em_", "<div><strong>bold</strong> and <em>it</em> and <em>em</em></div>"},
+		{"strike", "~~gone~~", "<div><del>gone</del></div>"},
+		{"snake_case kept", "use snake_case_name", "<div>use snake_case_name</div>"},
+		{"inline code", "run `a *b*`", "<div>run <code>a *b*</code></div>"},
+		{"link", "[docs](https://example.com)", `<div><a href="https://example.com">docs</a></div>`},
+		{"autolink", "<https://example.com>", `<div><a href="https://example.com">https://example.com</a></div>`},
+		{"bullet list", "- one\n- two", "<ul><li>one</li><li>two</li></ul>"},
+		{"numbered list", "1. one\n2. two", "<ol><li>one</li><li>two</li></ol>"},
+		{"quote", "> quoted", "<blockquote><div>quoted</div></blockquote>"},
+		{"code block", "```go\nx := <a>\n```", "<pre>x := &lt;a&gt;</pre>"},
+		{"paragraph then list", "Intro\n- item", "<div>Intro</div><ul><li>item</li></ul>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToHTML(tt.md); got != tt.want {
+				t.Errorf("markdownToHTML(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTMLLists(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"nested bullets", "- a\n  - b\n    - c\n- d", "<ul><li>a<ul><li>b<ul><li>c</li></ul></li></ul></li><li>d</li></ul>"},
+		{"bullets inside numbers", "1. a\n   - b\n2. c", "<ol><li>a<ul><li>b</li></ul></li><li>c</li></ol>"},
+		{"mixed types at one level", "- a\n1. b\n2. c", "<ul><li>a</li></ul><ol><li>b</li><li>c</li></ol>"},
+		{"loose list", "- a\n\n- b", "<ul><li>a</li><li>b</li></ul>"},
+		{"continuation line", "- a\n  continued", "<ul><li>a continued</li></ul>"},
+		{"tab indent", "-\ta\n\t- b", "<ul><li>a<ul><li>b</li></ul></li></ul>"},
+		{"formatted items", "* **bold** item\n+ [link](https://example.com)", `<ul><li><strong>bold</strong> item</li><li><a href="https://example.com">link</a></li></ul>`},
+		{"list in quote", "> - a\n> - b", "<blockquote><ul><li>a</li><li>b</li></ul></blockquote>"},
+		{"list ends at heading", "- a\n# Next", "<ul><li>a</li></ul><h1>Next</h1>"},
+		{"paren numbers", "1) a\n2) b", "<ol><li>a</li><li>b</li></ol>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToHTML(tt.md); got != tt.want {
+				t.Errorf("markdownToHTML(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToHTMLEdgeCases(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"blank lines only", "\n\n", ""},
+		{"crlf", "a\r\nb", "<div>a b</div>"},
+		{"backslash escapes", `\*not em\* \_nor this\_`, "<div>*not em* _nor this_</div>"},
+		{"lone asterisks", "2 * 3 * 4", "<div>2 * 3 * 4</div>"},
+		{"bold italic", "***both***", "<div><strong><em>both</em></strong></div>"},
+		{"bold with underscores", "__bold__", "<div><strong>bold</strong></div>"},
+		{"double backtick code", "`` a `b` ``", "<div><code>a `b`</code></div>"},
+		{"html in code", "`<b>`", "<div><code>&lt;b&gt;</code></div>"},
+		{"link text formatted", `[a *b*](https://x.com/?a=1&b=2 "title")`, `<div><a href="https://x.com/?a=1&amp;b=2">a <em>b</em></a></div>`},
+		{"code in link text", "[`code`](https://x.y)", `<div><a href="https://x.y"><code>code</code></a></div>`},
+		{"escape in link text", `[a\*b\_c](https://x.y)`, `<div><a href="https://x.y">a*b_c</a></div>`},
+		{"image becomes link", "![logo](https://x.com/y.png)", `<div><a href="https://x.com/y.png">logo</a></div>`},
+		{"closing hashes", "# Title #", "<h1>Title</h1>"},
+		{"heading interrupts paragraph", "Line\n# Head\nMore", "<div>Line</div><h1>Head</h1><div>More</div>"},
+		{"rule separates", "One\n\n---\n\nTwo", "<div>One</div><div>Two</div>"},
+		{"star rule", "* * *", ""},
+		{"tilde fence", "~~~\ncode\n~~~", "<pre>code</pre>"},
+		{"unclosed fence", "```\nunclosed", "<pre>unclosed</pre>"},
+		{"code keeps markdown", "```\n**x**\n- y\n```", "<pre>**x**\n- y</pre>"},
+		{"nested quote", "> one\n>\n> > nested", "<blockquote><div>one</div><blockquote><div>nested</div></blockquote></blockquote>"},
+		{"backslash break", "One\\\nTwo", "<div>One<br>Two</div>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownToHTML(tt.md); got != tt.want {
+				t.Errorf("markdownToHTML(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	var m markdownFlags
+	if m.enabled() {
+		t.Error("enabled() = true without config or flags")
+	}
+	if got := m.convert("**x**"); got != "**x**" {
+		t.Errorf("convert() = %q, want text unchanged", got)
+	}
+
+	if err := config.Save(&config.Config{Markdown: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !m.enabled() {
+		t.Error("enabled() = false with markdown set in config")
+	}
+	if got := m.convert("**x**"); got != "<div><strong>x</strong></div>" {
+		t.Errorf("convert() = %q", got)
+	}
+
+	m.parse("--no-markdown")
+	if m.enabled() {
+		t.Error("enabled() = true with --no-markdown")
+	}
+	m.parse("--markdown")
+	if !m.enabled() {
+		t.Error("enabled() = false with --markdown")
+	}
+}
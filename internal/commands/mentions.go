@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+// mentionResolver resolves @mentions against the people cache, fetching
+// people when the cache is stale or a mention is not found in it
+type mentionResolver struct {
+	cl     *client.Client
+	people []Person
+	loaded bool
+	fresh  bool
+}
+
+// load reads people from the cache, or from the API when the cache is
+// stale or refresh is set. A cache that cannot be written is not an error.
+func (r *mentionResolver) load(refresh bool) error {
+	path := config.PeopleCacheFile()
+	if !refresh {
+		if cache, err := config.LoadPeopleCache(path); err == nil && cache.Fresh(time.Now()) {
+			r.people = make([]Person, len(cache.People))
+			for i, p := range cache.People {
+				r.people[i] = Person{ID: p.ID, Name: p.Name, EmailAddress: p.EmailAddress, AttachableSGID: p.AttachableSGID}
+			}
+			r.loaded = true
+			return nil
+		}
+	}
+
+	people, err := fetchMentionablePeople(r.cl)
+	if err != nil {
+		return err
+	}
+	r.people, r.loaded, r.fresh = people, true, true
+
+	cache := &config.PeopleCache{UpdatedAt: time.Now(), People: make([]config.CachedPerson, len(people))}
+	for i, p := range people {
+		cache.People[i] = config.CachedPerson{ID: p.ID, Name: p.Name, EmailAddress: p.EmailAddress, AttachableSGID: p.AttachableSGID}
+	}
+	_ = config.SavePeopleCache(path, cache)
+	return nil
+}
+
+// fetchMentionablePeople gets everyone visible plus everyone pingable,
+// which includes clients and people from other companies
+func fetchMentionablePeople(cl *client.Client) ([]Person, error) {
+	people, err := fetchPeople(cl)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := cl.GetAll("/circles/people.json")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool, len(people))
+	for _, p := range people {
+		seen[p.ID] = true
+	}
+	for _, personJSON := range pages {
+		var p Person
+		if err := json.Unmarshal(personJSON, &p); err != nil {
+			return nil, err
+		}
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			people = append(people, p)
+		}
+	}
+	return people, nil
+}
+
+// resolve finds the person for a mention, refetching people once if the
+// cached ones do not match
+func (r *mentionResolver) resolve(ref string, words []string) (Person, int, error) {
+	if !r.loaded {
+		if err := r.load(false); err != nil {
+			return Person{}, 0, err
+		}
+	}
+
+	p, n, err := matchMention(r.people, ref, words)
+	if (err != nil || p.AttachableSGID == "") && !r.fresh {
+		if err := r.load(true); err != nil {
+			return Person{}, 0, err
+		}
+		p, n, err = matchMention(r.people, ref, words)
+	}
+	if err != nil {
+		return Person{}, 0, fmt.Errorf("mention @%s: %w", ref, err)
+	}
+	if p.AttachableSGID == "" {
+		return Person{}, 0, fmt.Errorf("mention @%s: %s cannot be mentioned", ref, p.Name)
+	}
+	return p, n, nil
+}
+
+// matchMention resolves a mention with resolvePerson. A name followed by
+// words that complete someone's full name, as in "@Jane Doe", matches that
+// person; the number of words used is returned.
+func matchMention(people []Person, ref string, words []string) (Person, int, error) {
+	if !strings.Contains(ref, "@") {
+		for n := min(len(words), 3); n > 0; n-- {
+			name := ref + " " + strings.Join(words[:n], " ")
+			var found []Person
+			for _, p := range people {
+				if strings.EqualFold(p.Name, name) {
+					found = append(found, p)
+				}
+			}
+			if len(found) > 0 {
+				p, err := resolvePerson(found, name)
+				return p, n, err
+			}
+		}
+	}
+
+	p, err := resolvePerson(people, ref)
+	return p, 0, err
+}
+
+var (
+	mentionTagRegex   = regexp.MustCompile(`<(/?)([a-zA-Z][\w-]*)[^>]*>`)
+	mentionTokenRegex = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@./])@([\p{L}\p{N}_.+-]+@[\p{L}\p{N}_-]+(?:\.[\p{L}\p{N}_-]+)+|\p{L}[\p{L}\p{N}_.'-]*)`)
+	mentionWordRegex  = regexp.MustCompile(`^ (\p{L}[\p{L}'-]*)`)
+)
+
+// mentionSkipTags are elements whose text is never scanned for mentions
+var mentionSkipTags = map[string]bool{"pre": true, "code": true, "a": true, "bc-attachment": true}
+
+// replaceMentions replaces @Name and @email tokens in the text of rich
+// content with mention attachments, returning how many were replaced.
+// Tags, attributes, code and existing links and attachments are left alone.
+func replaceMentions(content string, resolve func(ref string, words []string) (Person, int, error)) (string, int, error) {
+	var b strings.Builder
+	count := 0
+	skip := 0
+
+	last := 0
+	text := func(s string) error {
+		if skip > 0 || !strings.Contains(s, "@") {
+			b.WriteString(s)
+			return nil
+		}
+		replaced, n, err := replaceMentionsInText(html.UnescapeString(s), resolve)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			b.WriteString(s)
+			return nil
+		}
+		b.WriteString(replaced)
+		count += n
+		return nil
+	}
+
+	for _, m := range mentionTagRegex.FindAllStringSubmatchIndex(content, -1) {
+		if err := text(content[last:m[0]]); err != nil {
+			return "", 0, err
+		}
+		last = m[1]
+		b.WriteString(content[m[0]:m[1]])
+
+		if mentionSkipTags[strings.ToLower(content[m[4]:m[5]])] {
+			if m[3] > m[2] {
+				skip = max(skip-1, 0)
+			} else {
+				skip++
+			}
+		}
+	}
+	if err := text(content[last:]); err != nil {
+		return "", 0, err
+	}
+
+	return b.String(), count, nil
+}
+
+// replaceMentionsInText replaces mentions in unescaped text and returns
+// it escaped again
+func replaceMentionsInText(s string, resolve func(ref string, words []string) (Person, int, error)) (string, int, error) {
+	var b strings.Builder
+	count := 0
+
+	for {
+		m := mentionTokenRegex.FindStringSubmatchIndex(s)
+		if m == nil {
+			break
+		}
+		ref := s[m[2]:m[3]]
+		end := m[3]
+		if !strings.Contains(ref, "@") {
+			// A sentence can end right after a name
+			ref = strings.TrimRight(ref, ".'-")
+			end = m[2] + len(ref)
+		}
+
+		var words []string
+		rest := s[end:]
+		for len(words) < 3 {
+			w := mentionWordRegex.FindStringSubmatch(rest)
+			if w == nil {
+				break
+			}
+			words = append(words, w[1])
+			rest = rest[len(w[0]):]
+		}
+
+		p, n, err := resolve(ref, words)
+		if err != nil {
+			return "", 0, err
+		}
+		for _, w := range words[:n] {
+			end += len(" " + w)
+		}
+
+		b.WriteString(html.EscapeString(s[:m[2]-1]))
+		b.WriteString(attachmentHTML(p.AttachableSGID))
+		s = s[end:]
+		count++
+	}
+
+	b.WriteString(html.EscapeString(s))
+	return b.String(), count, nil
+}
+
+// expandMentions replaces the @mentions in rich content before it is
+// posted. Unknown and ambiguous names are errors.
+func expandMentions(cl *client.Client, content string) (string, int, error) {
+	r := &mentionResolver{cl: cl}
+	return replaceMentions(content, r.resolve)
+}
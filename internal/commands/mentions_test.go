@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplaceMentions(t *testing.T) {
+	people := []Person{
+		{ID: 1, Name: "Jane Doe", EmailAddress: "jane@example.com", AttachableSGID: "sgid-jane"},
+		{ID: 2, Name: "Jane Smith", EmailAddress: "jsmith@example.com", AttachableSGID: "sgid-smith"},
+		{ID: 3, Name: "Bob O'Brien", EmailAddress: "bob@example.com", AttachableSGID: "sgid-bob"},
+	}
+	resolve := func(ref string, words []string) (Person, int, error) {
+		return matchMention(people, ref, words)
+	}
+	jane := attachmentHTML("sgid-jane")
+	bob := attachmentHTML("sgid-bob")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		count   int
+	}{
+		{"full name", "Hi @Jane Doe, thanks", "Hi " + jane + ", thanks", 1},
+		{"email", "<div>cc @jane@example.com.</div>", "<div>cc " + jane + ".</div>", 1},
+		{"email local part", "@bob see this", bob + " see this", 1},
+		{"name without spaces", "@JaneDoe done", jane + " done", 1},
+		{"sentence end", "Ask @Bob.", "Ask " + bob + ".", 1},
+		{"full name with apostrophe", "<div>@Bob O&#39;Brien &amp; co</div>", "<div>" + bob + " &amp; co</div>", 1},
+		{"two mentions", "@jane@example.com and @bob", jane + " and " + bob, 2},
+		{"plain email untouched", "write to jane@example.com", "write to jane@example.com", 0},
+		{"code untouched", "<pre>@Override</pre><div>run <code>@bob</code></div>", "<pre>@Override</pre><div>run <code>@bob</code></div>", 0},
+		{"link untouched", `<a href="mailto:x@example.com">@bob</a>`, `<a href="mailto:x@example.com">@bob</a>`, 0},
+		{"lone at sign", "meet @ noon", "meet @ noon", 0},
+		{"entities kept without mentions", "a &amp; b", "a &amp; b", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count, err := replaceMentions(tt.content, resolve)
+			if err != nil {
+				t.Fatalf("replaceMentions() error = %v", err)
+			}
+			if got != tt.want || count != tt.count {
+				t.Errorf("replaceMentions(%q) = %q, %d, want %q, %d", tt.content, got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
+
+func TestReplaceMentionsErrors(t *testing.T) {
+	people := []Person{
+		{ID: 1, Name: "Jane Doe", EmailAddress: "jane@example.com", AttachableSGID: "sgid-jane"},
+		{ID: 2, Name: "Sam Lee", EmailAddress: "slee@example.com", AttachableSGID: "sgid-lee"},
+		{ID: 3, Name: "Sam Park", EmailAddress: "spark@example.com", AttachableSGID: "sgid-park"},
+	}
+	resolve := func(ref string, words []string) (Person, int, error) {
+		return matchMention(people, ref, words)
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"Hi @Sam", "ambiguous"},
+		{"Hi @nobody", "no person matching"},
+	}
+
+	for _, tt := range tests {
+		_, _, err := replaceMentions(tt.content, resolve)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("replaceMentions(%q) error = %v, want %q", tt.content, err, tt.want)
+		}
+	}
+}
+
+func TestReplaceMentionsSkipsMarkdownCode(t *testing.T) {
+	people := []Person{{ID: 1, Name: "Bob Jones", EmailAddress: "bob@example.com", AttachableSGID: "sgid-bob"}}
+	resolve := func(ref string, words []string) (Person, int, error) {
+		return matchMention(people, ref, words)
+	}
+
+	content := markdownToHTML("@bob run `npm i @types/node`")
+	got, count, err := replaceMentions(content, resolve)
+	if err != nil {
+		t.Fatalf("replaceMentions() error = %v", err)
+	}
+	want := "<div>" + attachmentHTML("sgid-bob") + " run <code>npm i @types/node</code></div>"
+	if got != want || count != 1 {
+		t.Errorf("replaceMentions(%q) = %q, %d, want %q, 1", content, got, count, want)
+	}
+}
+
+func TestContentFlagsExpandsMentions(t *testing.T) {
+	content := "hi @Jane"
+	tests := []struct {
+		name  string
+		flags contentFlags
+		want  bool
+	}{
+		{"content", contentFlags{Content: &content}, true},
+		{"file", contentFlags{File: "notes.md"}, true},
+		{"stdin", contentFlags{Stdin: true}, false},
+		{"piped file", contentFlags{File: "-"}, false},
+		{"stdin with --mentions", contentFlags{Stdin: true, Mentions: true}, true},
+		{"--no-mentions", contentFlags{Content: &content, NoMentions: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flags.expandsMentions(); got != tt.want {
+				t.Errorf("expandsMentions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Without expansion nothing is resolved, so piped logs post as typed
+	f := contentFlags{Stdin: true}
+	got, err := f.mentions(nil, "npm i @types/node && ping @here")
+	if err != nil || got != "npm i @types/node && ping @here" {
+		t.Errorf("mentions() = %q, %v", got, err)
+	}
+}
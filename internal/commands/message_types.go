@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -17,6 +18,44 @@ type MessageType struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// fetchMessageTypes gets the message types of a project
+func fetchMessageTypes(cl *client.Client, projectID string) ([]MessageType, error) {
+	data, err := cl.Get("/buckets/" + projectID + "/categories.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var types []MessageType
+	if err := json.Unmarshal(data, &types); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// resolveMessageTypeID returns ref as an ID if it is numeric, otherwise
+// the ID of the message type whose name matches it
+func resolveMessageTypeID(cl *client.Client, projectID, ref string) (int, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return id, nil
+	}
+
+	types, err := fetchMessageTypes(cl, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.Name
+	}
+
+	idx, err := matchByName("message type", ref, names)
+	if err != nil {
+		return 0, err
+	}
+	return types[idx].ID, nil
+}
+
 // MessageTypesCmd lists message types
 type MessageTypesCmd struct{}
 
@@ -42,16 +81,11 @@ func (c *MessageTypesCmd) Run(args []string) error {
 		return err
 	}
 
-	data, err := cl.Get("/buckets/" + projectID + "/categories.json")
+	types, err := fetchMessageTypes(cl, projectID)
 	if err != nil {
 		return err
 	}
 
-	var types []MessageType
-	if err := json.Unmarshal(data, &types); err != nil {
-		return err
-	}
-
 	var pID int
 	fmt.Sscanf(projectID, "%d", &pID)
 
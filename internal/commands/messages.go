@@ -50,7 +50,10 @@ type Message struct {
 	CommentsCount int     `json:"comments_count"`
 	CommentsURL   string  `json:"comments_url"`
 	URL           string  `json:"app_url"`
+	Status        string  `json:"status"`
 	Creator       Creator `json:"creator"`
+
+	Category *MessageType `json:"category"`
 }
 
 type MessageListOutput struct {
@@ -124,6 +127,7 @@ type MessageDetailOutput struct {
 	UpdatedAt     string          `json:"updated_at"`
 	CommentsCount int             `json:"comments_count"`
 	URL           string          `json:"url"`
+	Category      string          `json:"category,omitempty"`
 	Comments      []CommentOutput `json:"comments,omitempty"`
 }
 
@@ -136,12 +140,23 @@ func (c *MessageCmd) Run(args []string) error {
 	// Parse message_id and flags
 	var messageID string
 	showComments := false
+	body := bodyMarkdown
 
 	for i := 0; i < len(remaining); i++ {
-		if remaining[i] == "--comments" {
+		switch remaining[i] {
+		case "--comments":
 			showComments = true
-		} else if messageID == "" {
-			messageID = remaining[i]
+		case "--body":
+			if i+1 < len(remaining) {
+				if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+					return err
+				}
+				i++
+			}
+		default:
+			if messageID == "" {
+				messageID = remaining[i]
+			}
 		}
 	}
 
@@ -168,16 +183,19 @@ func (c *MessageCmd) Run(args []string) error {
 	output := MessageDetailOutput{
 		ID:            message.ID,
 		Subject:       message.Subject,
-		Content:       stripHTML(message.Content),
+		Content:       renderBody(body, message.Content),
 		Creator:       message.Creator.Name,
 		CreatedAt:     message.CreatedAt,
 		UpdatedAt:     message.UpdatedAt,
 		CommentsCount: message.CommentsCount,
 		URL:           message.URL,
 	}
+	if message.Category != nil {
+		output.Category = message.Category.Name
+	}
 
 	if showComments && message.CommentsURL != "" {
-		comments, err := fetchComments(cl, message.CommentsURL)
+		comments, err := fetchComments(cl, message.CommentsURL, body)
 		if err != nil {
 			return err
 		}
@@ -190,10 +208,29 @@ func (c *MessageCmd) Run(args []string) error {
 type MessageCreateCmd struct{}
 
 type MessageCreateOutput struct {
-	Status  string `json:"status"`
-	ID      int    `json:"id"`
-	Subject string `json:"subject"`
-	Message string `json:"message"`
+	Status        string `json:"status"`
+	ID            int    `json:"id"`
+	Subject       string `json:"subject"`
+	MessageStatus string `json:"message_status,omitempty"`
+	Category      string `json:"category,omitempty"`
+	// AttachableSGIDs lists the files embedded with --attach
+	AttachableSGIDs []string `json:"attachable_sgids,omitempty"`
+	Message         string   `json:"message"`
+}
+
+// messageWriteOutput summarizes a created or updated message
+func messageWriteOutput(m Message, verb string) MessageCreateOutput {
+	output := MessageCreateOutput{
+		Status:        "ok",
+		ID:            m.ID,
+		Subject:       m.Subject,
+		MessageStatus: m.Status,
+		Message:       fmt.Sprintf("Message '%s' %s", m.Subject, verb),
+	}
+	if m.Category != nil {
+		output.Category = m.Category.Name
+	}
+	return output
 }
 
 func (c *MessageCreateCmd) Run(args []string) error {
@@ -203,7 +240,10 @@ func (c *MessageCreateCmd) Run(args []string) error {
 	}
 
 	// Parse flags
-	var subject, content string
+	var subject, category, subscribers string
+	var cf contentFlags
+	var af attachFlags
+	status := "active"
 
 	for i := 0; i < len(remaining); i++ {
 		switch remaining[i] {
@@ -212,23 +252,48 @@ func (c *MessageCreateCmd) Run(args []string) error {
 				subject = remaining[i+1]
 				i++
 			}
-		case "--content":
+		case "--category":
 			if i+1 < len(remaining) {
-				content = remaining[i+1]
+				category = remaining[i+1]
 				i++
 			}
+		case "--status":
+			if i+1 < len(remaining) {
+				status = remaining[i+1]
+				i++
+			}
+		case "--subscribers":
+			if i+1 < len(remaining) {
+				subscribers = remaining[i+1]
+				i++
+			}
+		default:
+			if !cf.parse(remaining, &i) {
+				af.parse(remaining, &i)
+			}
 		}
 	}
 
 	if subject == "" {
 		return errors.New("--subject required")
 	}
+	content, err := cf.read()
+	if err != nil {
+		return err
+	}
+	if status != "active" && status != "drafted" {
+		return errors.New("--status must be drafted or active")
+	}
 
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
+	if content, err = cf.mentions(cl, content); err != nil {
+		return err
+	}
+
 	_, board, err := fetchMessageBoard(cl, projectID)
 	if err != nil {
 		return err
@@ -237,7 +302,26 @@ func (c *MessageCreateCmd) Run(args []string) error {
 	// Create message
 	payload := map[string]any{
 		"subject": subject,
-		"status":  "active",
+		"status":  status,
+	}
+	if category != "" {
+		categoryID, err := resolveMessageTypeID(cl, projectID, category)
+		if err != nil {
+			return err
+		}
+		payload["category_id"] = categoryID
+	}
+	if subscribers != "" {
+		// Only these people are notified and subscribed
+		subscriberIDs, err := resolvePeopleList(cl, subscribers)
+		if err != nil {
+			return err
+		}
+		payload["subscriptions"] = subscriberIDs
+	}
+	content, sgids, err := af.embed(cl, content)
+	if err != nil {
+		return err
 	}
 	if content != "" {
 		payload["content"] = content
@@ -260,10 +344,145 @@ func (c *MessageCreateCmd) Run(args []string) error {
 		return err
 	}
 
-	return PrintJSON(MessageCreateOutput{
-		Status:  "ok",
-		ID:      created.ID,
-		Subject: created.Subject,
-		Message: fmt.Sprintf("Message '%s' created", created.Subject),
+	output := messageWriteOutput(created, "created")
+	output.AttachableSGIDs = sgids
+	return PrintJSON(output)
+}
+
+// MessageUpdateCmd edits the subject, content or category of a message
+type MessageUpdateCmd struct{}
+
+func (c *MessageUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp message-update [project_id] <message_id> [--subject <text>] [--content <html>|--content-file <file>|--stdin] [--markdown] [--category <id|name>]")
+	}
+	messageID := remaining[0]
+
+	var subject, category *string
+	var cf contentFlags
+
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--subject":
+			if i+1 < len(remaining) {
+				subject = &remaining[i+1]
+				i++
+			}
+		case "--category":
+			if i+1 < len(remaining) {
+				category = &remaining[i+1]
+				i++
+			}
+		default:
+			cf.parse(remaining, &i)
+		}
+	}
+
+	if subject == nil && !cf.given() && category == nil {
+		return errors.New("at least one of --subject, --content or --category required")
+	}
+	if subject != nil && *subject == "" {
+		return errors.New("--subject cannot be empty")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{}
+	if subject != nil {
+		payload["subject"] = *subject
+	}
+	if cf.given() {
+		content, err := cf.read()
+		if err != nil {
+			return err
+		}
+		if content, err = cf.mentions(cl, content); err != nil {
+			return err
+		}
+		payload["content"] = content
+	}
+	if category != nil {
+		// An empty category removes it
+		payload["category_id"] = nil
+		if *category != "" {
+			categoryID, err := resolveMessageTypeID(cl, projectID, *category)
+			if err != nil {
+				return err
+			}
+			payload["category_id"] = categoryID
+		}
+	}
+
+	data, err := cl.Put("/buckets/"+projectID+"/messages/"+messageID+".json", payload)
+	if err != nil {
+		return err
+	}
+
+	var updated Message
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return err
+	}
+
+	return PrintJSON(messageWriteOutput(updated, "updated"))
+}
+
+// MessagePinCmd pins a message to the top of the message board
+type MessagePinCmd struct{}
+
+func (c *MessagePinCmd) Run(args []string) error {
+	return setMessagePinned(args, true)
+}
+
+// MessageUnpinCmd unpins a message
+type MessageUnpinCmd struct{}
+
+func (c *MessageUnpinCmd) Run(args []string) error {
+	return setMessagePinned(args, false)
+}
+
+func setMessagePinned(args []string, pinned bool) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	command, verb := "message-unpin", "unpinned"
+	if pinned {
+		command, verb = "message-pin", "pinned"
+	}
+
+	if len(remaining) < 1 {
+		return fmt.Errorf("usage: basecamp %s [project_id] <message_id>", command)
+	}
+	messageID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	path := "/buckets/" + projectID + "/recordings/" + messageID + "/pin.json"
+	if pinned {
+		_, err = cl.Post(path, nil)
+	} else {
+		_, err = cl.Delete(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":     "ok",
+		"message_id": messageID,
+		"pinned":     pinned,
+		"message":    "Message " + verb,
 	})
 }
@@ -0,0 +1,575 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+// cardTransition records a card entering a column
+type cardTransition struct {
+	Column string
+	At     time.Time
+}
+
+// cardHistory is the column timeline of one card, oldest first. The first
+// transition is the card's creation into its initial column.
+type cardHistory struct {
+	ID          int
+	Title       string
+	CreatedAt   time.Time
+	Transitions []cardTransition
+}
+
+// columnAt returns the column the card was in at t, or "" if the card did
+// not exist yet
+func (h cardHistory) columnAt(t time.Time) string {
+	column := ""
+	for _, tr := range h.Transitions {
+		if tr.At.After(t) {
+			break
+		}
+		column = tr.Column
+	}
+	return column
+}
+
+// cardMoveAction is the event action Basecamp records when a card is
+// moved to another column. The event details name both columns:
+//
+//	{"old_column": {"id": 1, "title": "Todo"}, "new_column": {"id": 2, "title": "Doing"}}
+const cardMoveAction = "column_changed"
+
+// eventColumnChange extracts the source and destination column from a card
+// move event. ok is false for other events; a move event whose details do
+// not name the new column is an error.
+func eventColumnChange(e Event) (from, to string, ok bool, err error) {
+	if e.Action != cardMoveAction {
+		return "", "", false, nil
+	}
+
+	var details struct {
+		OldColumn *struct {
+			Title string `json:"title"`
+		} `json:"old_column"`
+		NewColumn *struct {
+			Title string `json:"title"`
+		} `json:"new_column"`
+	}
+	if len(e.Details) > 0 {
+		if err := json.Unmarshal(e.Details, &details); err != nil {
+			return "", "", false, fmt.Errorf("event %d: invalid %s details: %w", e.ID, cardMoveAction, err)
+		}
+	}
+	if details.NewColumn == nil || details.NewColumn.Title == "" {
+		return "", "", false, fmt.Errorf("event %d: %s details have no new_column", e.ID, cardMoveAction)
+	}
+	if details.OldColumn != nil {
+		from = details.OldColumn.Title
+	}
+	return from, details.NewColumn.Title, true, nil
+}
+
+// buildCardHistory turns a card's events into a column timeline.
+// fallbackColumn is used as the starting column when no move event says
+// where the card started; with no moves at all the card has been in
+// currentColumn since it was created. Move events that cannot be read are
+// skipped and described in the returned warnings.
+func buildCardHistory(card CardSummary, events []Event, currentColumn, fallbackColumn string) (cardHistory, []string, error) {
+	createdAt, err := time.Parse(time.RFC3339, card.CreatedAt)
+	if err != nil {
+		return cardHistory{}, nil, fmt.Errorf("card %d: invalid created_at: %w", card.ID, err)
+	}
+
+	history := cardHistory{ID: card.ID, Title: card.Title, CreatedAt: createdAt}
+
+	type move struct {
+		from string
+		cardTransition
+	}
+
+	var moves []move
+	var warnings []string
+	for _, e := range events {
+		from, to, ok, err := eventColumnChange(e)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("card %d: %v; event skipped", card.ID, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, e.CreatedAt)
+		if err != nil {
+			continue
+		}
+		moves = append(moves, move{from: from, cardTransition: cardTransition{Column: to, At: at}})
+	}
+
+	// Events are listed newest first
+	sort.SliceStable(moves, func(i, j int) bool { return moves[i].At.Before(moves[j].At) })
+
+	initial := currentColumn
+	if len(moves) > 0 {
+		initial = coalesce(moves[0].from, fallbackColumn)
+	}
+
+	history.Transitions = []cardTransition{{Column: initial, At: createdAt}}
+	for _, m := range moves {
+		history.Transitions = append(history.Transitions, m.cardTransition)
+	}
+	return history, warnings, nil
+}
+
+// DurationStats summarises a set of durations in days
+type DurationStats struct {
+	Count    int     `json:"count"`
+	MeanDays float64 `json:"mean_days"`
+	P50Days  float64 `json:"p50_days"`
+	P85Days  float64 `json:"p85_days"`
+	P95Days  float64 `json:"p95_days"`
+}
+
+type ColumnTimeInState struct {
+	Column    string  `json:"column"`
+	Cards     int     `json:"cards"`
+	MeanHours float64 `json:"mean_hours"`
+	P50Hours  float64 `json:"p50_hours"`
+	P85Hours  float64 `json:"p85_hours"`
+}
+
+type WeeklyThroughput struct {
+	WeekStart string `json:"week_start"`
+	Completed int    `json:"completed"`
+}
+
+type DailyWIP struct {
+	Date string `json:"date"`
+	WIP  int    `json:"wip"`
+}
+
+type CardFlow struct {
+	ID        int     `json:"id"`
+	Title     string  `json:"title"`
+	CreatedAt string  `json:"created_at"`
+	StartedAt string  `json:"started_at,omitempty"`
+	DoneAt    string  `json:"done_at,omitempty"`
+	LeadDays  float64 `json:"lead_days,omitempty"`
+	CycleDays float64 `json:"cycle_days,omitempty"`
+}
+
+type BoardMetrics struct {
+	BoardID       int                 `json:"board_id"`
+	BoardTitle    string              `json:"board_title"`
+	Since         string              `json:"since"`
+	GeneratedAt   string              `json:"generated_at"`
+	StartColumn   string              `json:"start_column"`
+	DoneColumn    string              `json:"done_column"`
+	CardsAnalyzed int                 `json:"cards_analyzed"`
+	TimeInState   []ColumnTimeInState `json:"time_in_state"`
+	LeadTime      DurationStats       `json:"lead_time"`
+	CycleTime     DurationStats       `json:"cycle_time"`
+	Throughput    []WeeklyThroughput  `json:"throughput"`
+	WIP           []DailyWIP          `json:"wip"`
+	Cards         []CardFlow          `json:"cards"`
+	Warnings      []string            `json:"warnings,omitempty"`
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func durationStats(days []float64) DurationStats {
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+	return DurationStats{
+		Count:    len(sorted),
+		MeanDays: round2(mean(sorted)),
+		P50Days:  round2(percentile(sorted, 50)),
+		P85Days:  round2(percentile(sorted, 85)),
+		P95Days:  round2(percentile(sorted, 95)),
+	}
+}
+
+// weekStart returns the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// computeBoardMetrics derives flow metrics from card histories. columns is
+// the board's column order. A card starts when it first enters any column
+// other than startColumn and is done when its latest transition is into
+// doneColumn. Only time after since is counted.
+func computeBoardMetrics(histories []cardHistory, columns []string, startColumn, doneColumn string, since, now time.Time) BoardMetrics {
+	metrics := BoardMetrics{
+		Since:         since.Format("2006-01-02"),
+		GeneratedAt:   now.UTC().Format(time.RFC3339),
+		StartColumn:   startColumn,
+		DoneColumn:    doneColumn,
+		CardsAnalyzed: len(histories),
+		TimeInState:   []ColumnTimeInState{},
+		Throughput:    []WeeklyThroughput{},
+		WIP:           []DailyWIP{},
+		Cards:         []CardFlow{},
+	}
+
+	hoursByColumn := make(map[string][]float64)
+	var leadDays, cycleDays []float64
+	throughput := make(map[string]int)
+
+	for _, h := range histories {
+		// Time in state, clipped to the [since, now] window
+		for i, tr := range h.Transitions {
+			end := now
+			if i+1 < len(h.Transitions) {
+				end = h.Transitions[i+1].At
+			}
+			start := tr.At
+			if start.Before(since) {
+				start = since
+			}
+			if !end.After(start) || tr.Column == doneColumn {
+				continue
+			}
+			hoursByColumn[tr.Column] = append(hoursByColumn[tr.Column], end.Sub(start).Hours())
+		}
+
+		flow := CardFlow{ID: h.ID, Title: h.Title, CreatedAt: h.CreatedAt.UTC().Format(time.RFC3339)}
+
+		var startedAt time.Time
+		for _, tr := range h.Transitions {
+			if tr.Column != startColumn {
+				startedAt = tr.At
+				break
+			}
+		}
+		if !startedAt.IsZero() {
+			flow.StartedAt = startedAt.UTC().Format(time.RFC3339)
+		}
+
+		last := h.Transitions[len(h.Transitions)-1]
+		if last.Column == doneColumn && !last.At.Before(since) {
+			flow.DoneAt = last.At.UTC().Format(time.RFC3339)
+			flow.LeadDays = round2(last.At.Sub(h.CreatedAt).Hours() / 24)
+			leadDays = append(leadDays, flow.LeadDays)
+			if !startedAt.IsZero() {
+				flow.CycleDays = round2(last.At.Sub(startedAt).Hours() / 24)
+				cycleDays = append(cycleDays, flow.CycleDays)
+			}
+			throughput[weekStart(last.At).Format("2006-01-02")]++
+		}
+
+		metrics.Cards = append(metrics.Cards, flow)
+	}
+
+	for _, column := range columns {
+		if column == doneColumn {
+			continue
+		}
+		hours := hoursByColumn[column]
+		sort.Float64s(hours)
+		metrics.TimeInState = append(metrics.TimeInState, ColumnTimeInState{
+			Column:    column,
+			Cards:     len(hours),
+			MeanHours: round2(mean(hours)),
+			P50Hours:  round2(percentile(hours, 50)),
+			P85Hours:  round2(percentile(hours, 85)),
+		})
+	}
+
+	metrics.LeadTime = durationStats(leadDays)
+	metrics.CycleTime = durationStats(cycleDays)
+
+	// Every week in the window is listed so charts have no gaps
+	for week := weekStart(since); !week.After(now); week = week.AddDate(0, 0, 7) {
+		key := week.Format("2006-01-02")
+		metrics.Throughput = append(metrics.Throughput, WeeklyThroughput{WeekStart: key, Completed: throughput[key]})
+	}
+
+	// WIP is sampled at the end of each day
+	for day := since; !day.After(now); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1).Add(-time.Second)
+		if endOfDay.After(now) {
+			endOfDay = now
+		}
+		wip := 0
+		for _, h := range histories {
+			column := h.columnAt(endOfDay)
+			if column != "" && column != startColumn && column != doneColumn {
+				wip++
+			}
+		}
+		metrics.WIP = append(metrics.WIP, DailyWIP{Date: day.Format("2006-01-02"), WIP: wip})
+	}
+
+	return metrics
+}
+
+// writeMetricsCSV writes one CSV file per metric into dir
+func writeMetricsCSV(dir string, m BoardMetrics) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+
+	tables := []struct {
+		name string
+		rows [][]string
+	}{
+		{"time_in_state.csv", [][]string{{"column", "cards", "mean_hours", "p50_hours", "p85_hours"}}},
+		{"throughput.csv", [][]string{{"week_start", "completed"}}},
+		{"wip.csv", [][]string{{"date", "wip"}}},
+		{"cards.csv", [][]string{{"id", "title", "created_at", "started_at", "done_at", "lead_days", "cycle_days"}}},
+	}
+
+	for _, c := range m.TimeInState {
+		tables[0].rows = append(tables[0].rows, []string{c.Column, strconv.Itoa(c.Cards), f(c.MeanHours), f(c.P50Hours), f(c.P85Hours)})
+	}
+	for _, w := range m.Throughput {
+		tables[1].rows = append(tables[1].rows, []string{w.WeekStart, strconv.Itoa(w.Completed)})
+	}
+	for _, d := range m.WIP {
+		tables[2].rows = append(tables[2].rows, []string{d.Date, strconv.Itoa(d.WIP)})
+	}
+	for _, c := range m.Cards {
+		lead, cycle := "", ""
+		if c.DoneAt != "" {
+			lead = f(c.LeadDays)
+			if c.StartedAt != "" {
+				cycle = f(c.CycleDays)
+			}
+		}
+		tables[3].rows = append(tables[3].rows, []string{strconv.Itoa(c.ID), c.Title, c.CreatedAt, c.StartedAt, c.DoneAt, lead, cycle})
+	}
+
+	var files []string
+	for _, table := range tables {
+		path := filepath.Join(dir, table.name)
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		w := csv.NewWriter(file)
+		err = w.WriteAll(table.rows)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// BoardMetricsCmd computes kanban flow metrics for a card table
+type BoardMetricsCmd struct{}
+
+func (c *BoardMetricsCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	// Parse board_id and flags
+	var boardID, sinceStr, csvDir, startColumn, doneColumn string
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--since":
+			if i+1 < len(remaining) {
+				sinceStr = remaining[i+1]
+				i++
+			}
+		case "--csv":
+			if i+1 < len(remaining) {
+				csvDir = remaining[i+1]
+				i++
+			}
+		case "--start":
+			if i+1 < len(remaining) {
+				startColumn = remaining[i+1]
+				i++
+			}
+		case "--done":
+			if i+1 < len(remaining) {
+				doneColumn = remaining[i+1]
+				i++
+			}
+		default:
+			if boardID == "" {
+				boardID = remaining[i]
+			}
+		}
+	}
+
+	if boardID == "" {
+		return errors.New("usage: basecamp board-metrics [project_id] <board_id> [--since <date>] [--start <column>] [--done <column>] [--csv <dir>]")
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -84)
+	if sinceStr != "" {
+		since, err = time.Parse("2006-01-02", sinceStr)
+		if err != nil {
+			return errors.New("--since must be a date (YYYY-MM-DD)")
+		}
+	} else {
+		y, m, d := since.Date()
+		since = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Get("/buckets/" + projectID + "/card_tables/" + boardID + ".json")
+	if err != nil {
+		return err
+	}
+
+	var cardTable CardTableDetail
+	if err := json.Unmarshal(data, &cardTable); err != nil {
+		return err
+	}
+	if len(cardTable.Lists) == 0 {
+		return errors.New("board has no columns")
+	}
+
+	columns := make([]string, len(cardTable.Lists))
+	for i, list := range cardTable.Lists {
+		columns[i] = list.Title
+	}
+
+	// Default to the first column as the backlog and the last as done
+	startColumn, err = matchColumn(columns, startColumn, columns[0])
+	if err != nil {
+		return err
+	}
+	doneColumn, err = matchColumn(columns, doneColumn, columns[len(columns)-1])
+	if err != nil {
+		return err
+	}
+
+	var histories []cardHistory
+	// Without any move events every card looks like it never left the
+	// column it is in now, so say so instead of reporting empty flow
+	cardsWithEvents, moves := 0, 0
+	var warnings []string
+	for _, list := range cardTable.Lists {
+		if list.CardsCount == 0 {
+			continue
+		}
+
+		cardsData, err := cl.GetAll(list.CardsURL)
+		if err != nil {
+			return err
+		}
+
+		for _, cardJSON := range cardsData {
+			var card CardSummary
+			if err := json.Unmarshal(cardJSON, &card); err != nil {
+				return err
+			}
+
+			eventsData, err := cl.GetAll(fmt.Sprintf("/buckets/%s/recordings/%d/events.json", projectID, card.ID))
+			if err != nil {
+				return err
+			}
+
+			events := make([]Event, len(eventsData))
+			for i, eventJSON := range eventsData {
+				if err := json.Unmarshal(eventJSON, &events[i]); err != nil {
+					return err
+				}
+			}
+
+			history, skipped, err := buildCardHistory(card, events, list.Title, startColumn)
+			if err != nil {
+				return err
+			}
+			warnings = append(warnings, skipped...)
+			if len(events) > 0 {
+				cardsWithEvents++
+			}
+			moves += len(history.Transitions) - 1
+
+			// Cards finished before the window add nothing but noise
+			last := history.Transitions[len(history.Transitions)-1]
+			if last.Column == doneColumn && last.At.Before(since) {
+				continue
+			}
+			histories = append(histories, history)
+		}
+	}
+
+	metrics := computeBoardMetrics(histories, columns, startColumn, doneColumn, since, now)
+	metrics.BoardID = cardTable.ID
+	metrics.BoardTitle = cardTable.Title
+	if cardsWithEvents > 0 && moves == 0 {
+		warnings = append(warnings, fmt.Sprintf("no %s events found for %d cards; every card is treated as never having moved", cardMoveAction, cardsWithEvents))
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "warning: "+warning)
+	}
+	metrics.Warnings = warnings
+
+	if csvDir != "" {
+		files, err := writeMetricsCSV(csvDir, metrics)
+		if err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "CSV written: %s\n", strings.Join(files, ", "))
+	}
+
+	return PrintJSON(metrics)
+}
+
+// matchColumn finds a column by case-insensitive title, or returns def if
+// name is empty
+func matchColumn(columns []string, name, def string) (string, error) {
+	if name == "" {
+		return def, nil
+	}
+	for _, column := range columns {
+		if strings.EqualFold(column, name) {
+			return column, nil
+		}
+	}
+	return "", fmt.Errorf("column '%s' not found. Available columns: %s", name, strings.Join(columns, ", "))
+}
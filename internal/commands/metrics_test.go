@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func moveEvent(at, from, to string) Event {
+	details, _ := json.Marshal(map[string]any{
+		"old_column": map[string]string{"title": from},
+		"new_column": map[string]string{"title": to},
+	})
+	return Event{Action: cardMoveAction, CreatedAt: at, Details: details}
+}
+
+func TestEventColumnChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		wantFrom string
+		wantTo   string
+		wantOK   bool
+		wantErr  bool
+	}{
+		{
+			name:     "object details",
+			event:    moveEvent("2026-01-01T00:00:00Z", "Todo", "Doing"),
+			wantFrom: "Todo",
+			wantTo:   "Doing",
+			wantOK:   true,
+		},
+		{
+			name:   "no old column",
+			event:  Event{Action: cardMoveAction, Details: json.RawMessage(`{"new_column":{"id":2,"title":"Done"}}`)},
+			wantTo: "Done",
+			wantOK: true,
+		},
+		{
+			name:   "not a move",
+			event:  Event{Action: "completed", Details: json.RawMessage(`{"new_column":{"title":"Done"}}`)},
+			wantOK: false,
+		},
+		{
+			name:   "other actions mentioning moves",
+			event:  Event{Action: "moved", Details: json.RawMessage(`{"new_column":{"title":"Done"}}`)},
+			wantOK: false,
+		},
+		{
+			name:    "move without details",
+			event:   Event{Action: cardMoveAction},
+			wantErr: true,
+		},
+		{
+			name:    "move with unexpected details",
+			event:   Event{Action: cardMoveAction, Details: json.RawMessage(`{"column":"Done"}`)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok, err := eventColumnChange(tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("eventColumnChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK || from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("eventColumnChange() = (%q, %q, %v), want (%q, %q, %v)", from, to, ok, tt.wantFrom, tt.wantTo, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBuildCardHistory(t *testing.T) {
+	card := CardSummary{ID: 1, Title: "Card", CreatedAt: "2026-01-01T00:00:00Z"}
+
+	t.Run("no moves", func(t *testing.T) {
+		h, _, err := buildCardHistory(card, nil, "Doing", "Todo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(h.Transitions) != 1 || h.Transitions[0].Column != "Doing" {
+			t.Errorf("Transitions = %+v, want created in Doing", h.Transitions)
+		}
+	})
+
+	t.Run("moves sorted oldest first", func(t *testing.T) {
+		events := []Event{
+			moveEvent("2026-01-05T00:00:00Z", "Doing", "Done"),
+			{Action: "commented", CreatedAt: "2026-01-03T00:00:00Z"},
+			moveEvent("2026-01-02T00:00:00Z", "Todo", "Doing"),
+		}
+		h, _, err := buildCardHistory(card, events, "Done", "Backlog")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"Todo", "Doing", "Done"}
+		if len(h.Transitions) != len(want) {
+			t.Fatalf("Transitions = %+v, want %v", h.Transitions, want)
+		}
+		for i, column := range want {
+			if h.Transitions[i].Column != column {
+				t.Errorf("Transitions[%d] = %s, want %s", i, h.Transitions[i].Column, column)
+			}
+		}
+		if got := h.columnAt(mustTime(t, "2026-01-03T00:00:00Z")); got != "Doing" {
+			t.Errorf("columnAt() = %s, want Doing", got)
+		}
+		if got := h.columnAt(mustTime(t, "2025-12-31T00:00:00Z")); got != "" {
+			t.Errorf("columnAt() before creation = %q, want empty", got)
+		}
+	})
+
+	t.Run("unreadable move event", func(t *testing.T) {
+		events := []Event{
+			moveEvent("2026-01-05T00:00:00Z", "Doing", "Done"),
+			{ID: 7, Action: cardMoveAction, CreatedAt: "2026-01-03T00:00:00Z", Details: json.RawMessage(`{"to":"Done"}`)},
+			moveEvent("2026-01-02T00:00:00Z", "Todo", "Doing"),
+		}
+		h, warnings, err := buildCardHistory(card, events, "Done", "Backlog")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(h.Transitions) != 3 || h.Transitions[2].Column != "Done" {
+			t.Errorf("Transitions = %+v, want the readable moves kept", h.Transitions)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "event 7") {
+			t.Errorf("warnings = %q, want one for event 7", warnings)
+		}
+	})
+
+	t.Run("invalid created_at", func(t *testing.T) {
+		if _, _, err := buildCardHistory(CardSummary{ID: 2}, nil, "Todo", "Todo"); err == nil {
+			t.Error("expected error for missing created_at")
+		}
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{50, 5},
+		{85, 9},
+		{95, 10},
+		{100, 10},
+	}
+	for _, tt := range tests {
+		if got := percentile(values, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile(nil) = %v, want 0", got)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	// 2026-01-07 is a Wednesday, 2026-01-11 a Sunday
+	for _, day := range []string{"2026-01-05T09:00:00Z", "2026-01-07T09:00:00Z", "2026-01-11T23:00:00Z"} {
+		if got := weekStart(mustTime(t, day)).Format("2006-01-02"); got != "2026-01-05" {
+			t.Errorf("weekStart(%s) = %s, want 2026-01-05", day, got)
+		}
+	}
+}
+
+func TestComputeBoardMetrics(t *testing.T) {
+	columns := []string{"Todo", "Doing", "Done"}
+	since := mustTime(t, "2026-01-05T00:00:00Z")
+	now := mustTime(t, "2026-01-11T12:00:00Z")
+
+	histories := []cardHistory{
+		{
+			ID:        1,
+			CreatedAt: mustTime(t, "2026-01-05T00:00:00Z"),
+			Transitions: []cardTransition{
+				{Column: "Todo", At: mustTime(t, "2026-01-05T00:00:00Z")},
+				{Column: "Doing", At: mustTime(t, "2026-01-06T00:00:00Z")},
+				{Column: "Done", At: mustTime(t, "2026-01-08T00:00:00Z")},
+			},
+		},
+		{
+			ID:        2,
+			CreatedAt: mustTime(t, "2026-01-05T00:00:00Z"),
+			Transitions: []cardTransition{
+				{Column: "Todo", At: mustTime(t, "2026-01-05T00:00:00Z")},
+				{Column: "Doing", At: mustTime(t, "2026-01-09T00:00:00Z")},
+			},
+		},
+	}
+
+	m := computeBoardMetrics(histories, columns, "Todo", "Done", since, now)
+
+	if m.LeadTime.Count != 1 || m.LeadTime.P50Days != 3 {
+		t.Errorf("LeadTime = %+v, want 1 card at 3 days", m.LeadTime)
+	}
+	if m.CycleTime.Count != 1 || m.CycleTime.P50Days != 2 {
+		t.Errorf("CycleTime = %+v, want 1 card at 2 days", m.CycleTime)
+	}
+
+	if len(m.Throughput) != 1 || m.Throughput[0].WeekStart != "2026-01-05" || m.Throughput[0].Completed != 1 {
+		t.Errorf("Throughput = %+v, want 1 completed in week of 2026-01-05", m.Throughput)
+	}
+
+	// Done is excluded from time in state
+	if len(m.TimeInState) != 2 {
+		t.Fatalf("TimeInState = %+v, want Todo and Doing", m.TimeInState)
+	}
+	todo := m.TimeInState[0]
+	if todo.Column != "Todo" || todo.Cards != 2 || todo.P50Hours != 24 || todo.P85Hours != 96 {
+		t.Errorf("Todo time in state = %+v", todo)
+	}
+
+	wip := map[string]int{}
+	for _, d := range m.WIP {
+		wip[d.Date] = d.WIP
+	}
+	want := map[string]int{
+		"2026-01-05": 0,
+		"2026-01-06": 1,
+		"2026-01-07": 1,
+		"2026-01-08": 0,
+		"2026-01-09": 1,
+		"2026-01-11": 1,
+	}
+	for date, n := range want {
+		if wip[date] != n {
+			t.Errorf("WIP on %s = %d, want %d", date, wip[date], n)
+		}
+	}
+}
+
+func TestMatchColumn(t *testing.T) {
+	columns := []string{"Todo", "In Progress", "Done"}
+
+	if got, _ := matchColumn(columns, "", "Todo"); got != "Todo" {
+		t.Errorf("matchColumn default = %s, want Todo", got)
+	}
+	if got, _ := matchColumn(columns, "in progress", "Todo"); got != "In Progress" {
+		t.Errorf("matchColumn = %s, want In Progress", got)
+	}
+	if _, err := matchColumn(columns, "Shipped", "Todo"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
@@ -4,27 +4,31 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
 
 // Person represents a Basecamp user
 type Person struct {
-	ID           int     `json:"id"`
-	Name         string  `json:"name"`
-	EmailAddress string  `json:"email_address"`
-	Title        string  `json:"title"`
-	Bio          string  `json:"bio"`
-	Location     string  `json:"location"`
-	Admin        bool    `json:"admin"`
-	Owner        bool    `json:"owner"`
-	Client       bool    `json:"client"`
-	Employee     bool    `json:"employee"`
-	TimeZone     string  `json:"time_zone"`
-	AvatarURL    string  `json:"avatar_url"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
-	Company      Company `json:"company"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"email_address"`
+	Title        string `json:"title"`
+	Bio          string `json:"bio"`
+	Location     string `json:"location"`
+	Admin        bool   `json:"admin"`
+	Owner        bool   `json:"owner"`
+	Client       bool   `json:"client"`
+	Employee     bool   `json:"employee"`
+	TimeZone     string `json:"time_zone"`
+	AvatarURL    string `json:"avatar_url"`
+	// AttachableSGID embeds the person in rich text as a mention
+	AttachableSGID string  `json:"attachable_sgid"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	Company        Company `json:"company"`
 }
 
 type Company struct {
@@ -225,16 +229,11 @@ func (c *MyProfileCmd) Run(args []string) error {
 		return err
 	}
 
-	data, err := cl.Get("/my/profile.json")
+	person, err := fetchMyProfile(cl)
 	if err != nil {
 		return err
 	}
 
-	var person Person
-	if err := json.Unmarshal(data, &person); err != nil {
-		return err
-	}
-
 	return PrintJSON(PersonDetailOutput{
 		ID:        person.ID,
 		Name:      person.Name,
@@ -332,6 +331,114 @@ func (c *ProjectAccessCmd) Run(args []string) error {
 	return PrintJSON(output)
 }
 
+// fetchPeople gets every person visible to the authenticated user
+func fetchPeople(cl *client.Client) ([]Person, error) {
+	pages, err := cl.GetAll("/people.json")
+	if err != nil {
+		return nil, err
+	}
+
+	people := make([]Person, len(pages))
+	for i, personJSON := range pages {
+		if err := json.Unmarshal(personJSON, &people[i]); err != nil {
+			return nil, err
+		}
+	}
+	return people, nil
+}
+
+// resolvePerson finds a person by ID, email address, full name or first
+// name (case-insensitive). An error is returned if nothing matches or if
+// more than one person matches.
+func resolvePerson(people []Person, ref string) (Person, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return Person{}, errors.New("empty person reference")
+	}
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, p := range people {
+			if p.ID == id {
+				return p, nil
+			}
+		}
+		return Person{}, fmt.Errorf("no person with ID %d", id)
+	}
+
+	// Try exact matches first, then looser ones, so "jane@example.com"
+	// never becomes ambiguous because of another Jane.
+	matchers := []func(Person) bool{
+		func(p Person) bool { return strings.EqualFold(p.EmailAddress, ref) },
+		func(p Person) bool { return strings.EqualFold(p.Name, ref) },
+		func(p Person) bool { return strings.EqualFold(strings.ReplaceAll(p.Name, " ", ""), ref) },
+		func(p Person) bool {
+			local, _, _ := strings.Cut(p.EmailAddress, "@")
+			return strings.EqualFold(local, ref)
+		},
+		func(p Person) bool {
+			first, _, _ := strings.Cut(p.Name, " ")
+			return strings.EqualFold(first, ref)
+		},
+	}
+
+	for _, match := range matchers {
+		var found []Person
+		for _, p := range people {
+			if match(p) {
+				found = append(found, p)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], nil
+		}
+		if len(found) > 1 {
+			names := make([]string, len(found))
+			for i, p := range found {
+				names[i] = fmt.Sprintf("%s <%s>", p.Name, p.EmailAddress)
+			}
+			return Person{}, fmt.Errorf("'%s' is ambiguous: %s", ref, strings.Join(names, ", "))
+		}
+	}
+
+	return Person{}, fmt.Errorf("no person matching '%s'", ref)
+}
+
+// resolvePeopleList turns a comma-separated list of person IDs, names or
+// emails into IDs. People are only fetched when a name or email is given.
+func resolvePeopleList(cl *client.Client, list string) ([]int, error) {
+	refs := splitComma(list)
+	needsLookup := false
+	for _, ref := range refs {
+		if _, err := strconv.Atoi(ref); err != nil {
+			needsLookup = true
+			break
+		}
+	}
+
+	if !needsLookup {
+		return parseIDList(list), nil
+	}
+
+	people, err := fetchPeople(cl)
+	if err != nil {
+		return nil, err
+	}
+	return resolvePersonIDs(people, refs)
+}
+
+// resolvePersonIDs resolves each reference with resolvePerson
+func resolvePersonIDs(people []Person, refs []string) ([]int, error) {
+	ids := make([]int, 0, len(refs))
+	for _, ref := range refs {
+		p, err := resolvePerson(people, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, p.ID)
+	}
+	return ids, nil
+}
+
 // Helper to convert Person to PersonOutput
 func personToOutput(p Person) PersonOutput {
 	return PersonOutput{
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolvePerson(t *testing.T) {
+	people := []Person{
+		{ID: 1, Name: "Jane Doe", EmailAddress: "jane@example.com"},
+		{ID: 2, Name: "Jane Roe", EmailAddress: "jroe@example.com"},
+		{ID: 3, Name: "Bob Smith", EmailAddress: "bob@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantID  int
+		wantErr string
+	}{
+		{name: "by id", ref: "3", wantID: 3},
+		{name: "by email", ref: "JANE@example.com", wantID: 1},
+		{name: "by full name", ref: "jane roe", wantID: 2},
+		{name: "by name without spaces", ref: "BobSmith", wantID: 3},
+		{name: "by email local part", ref: "jroe", wantID: 2},
+		{name: "by first name", ref: "bob", wantID: 3},
+		{name: "email local part wins over first name", ref: "jane", wantID: 1},
+		{name: "unknown id", ref: "99", wantErr: "no person with ID 99"},
+		{name: "unknown name", ref: "carol", wantErr: "no person matching 'carol'"},
+		{name: "empty", ref: " ", wantErr: "empty person reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePerson(people, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolvePerson(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePerson(%q) error = %v", tt.ref, err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("resolvePerson(%q) = %d, want %d", tt.ref, got.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestResolvePersonAmbiguous(t *testing.T) {
+	people := []Person{
+		{ID: 1, Name: "Sam Lee", EmailAddress: "slee@example.com"},
+		{ID: 2, Name: "Sam Park", EmailAddress: "spark@example.com"},
+	}
+
+	_, err := resolvePerson(people, "sam")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+}
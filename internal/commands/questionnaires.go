@@ -228,7 +228,7 @@ func (c *QuestionCmd) Run(args []string) error {
 	}
 
 	if showComments && question.CommentsURL != "" {
-		comments, err := fetchComments(cl, question.CommentsURL)
+		comments, err := fetchComments(cl, question.CommentsURL, bodyText)
 		if err != nil {
 			return err
 		}
@@ -376,7 +376,7 @@ func (c *QuestionAnswerCmd) Run(args []string) error {
 	}
 
 	if showComments && answer.CommentsURL != "" {
-		comments, err := fetchComments(cl, answer.CommentsURL)
+		comments, err := fetchComments(cl, answer.CommentsURL, bodyText)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,582 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+type RecurringCmd struct{}
+
+// rrule is the subset of an iCalendar RRULE used by recurring todos
+type rrule struct {
+	Freq       string
+	Interval   int
+	ByDay      []rruleDay
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+// rruleDay is a BYDAY entry such as MO, or 1MO / -1FR in monthly rules
+type rruleDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// parseRRule parses rules like "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"
+func parseRRule(s string) (rrule, error) {
+	r := rrule{Interval: 1}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.ToUpper(s), "RRULE:"), ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return rrule{}, fmt.Errorf("invalid rrule part '%s'", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				r.Freq = value
+			default:
+				return rrule{}, fmt.Errorf("unsupported FREQ '%s' (use DAILY, WEEKLY, MONTHLY or YEARLY)", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return rrule{}, fmt.Errorf("invalid INTERVAL '%s'", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return rrule{}, fmt.Errorf("invalid COUNT '%s'", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := time.Parse("20060102", value[:min(len(value), 8)])
+			if err != nil {
+				return rrule{}, fmt.Errorf("invalid UNTIL '%s'", value)
+			}
+			r.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if len(day) < 2 {
+					return rrule{}, fmt.Errorf("invalid BYDAY '%s'", day)
+				}
+				weekday, ok := rruleWeekdays[day[len(day)-2:]]
+				if !ok {
+					return rrule{}, fmt.Errorf("invalid BYDAY '%s'", day)
+				}
+				entry := rruleDay{Weekday: weekday}
+				if prefix := day[:len(day)-2]; prefix != "" {
+					n, err := strconv.Atoi(prefix)
+					if err != nil || n == 0 || n < -5 || n > 5 {
+						return rrule{}, fmt.Errorf("invalid BYDAY '%s'", day)
+					}
+					entry.Ordinal = n
+				}
+				r.ByDay = append(r.ByDay, entry)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return rrule{}, fmt.Errorf("invalid BYMONTHDAY '%s'", day)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "WKST":
+			// Weeks always start on Monday here
+		default:
+			return rrule{}, fmt.Errorf("unsupported rrule part '%s'", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return rrule{}, errors.New("rrule requires FREQ")
+	}
+	return r, nil
+}
+
+// String formats the rule as an iCalendar RRULE value
+func (r rrule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, bd := range r.ByDay {
+			for code, weekday := range rruleWeekdays {
+				if weekday == bd.Weekday {
+					days[i] = code
+				}
+			}
+			if bd.Ordinal != 0 {
+				days[i] = strconv.Itoa(bd.Ordinal) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// periodStart returns the first day of the k-th period after start
+func (r rrule) periodStart(start time.Time, k int) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, 7*k*r.Interval)
+	case "MONTHLY":
+		return time.Date(start.Year(), start.Month()+time.Month(k*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+	case "YEARLY":
+		return time.Date(start.Year()+k*r.Interval, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return start.AddDate(0, 0, k*r.Interval)
+}
+
+// periodDates returns the candidate dates in the k-th period after start,
+// in order
+func (r rrule) periodDates(start time.Time, k int) []time.Time {
+	from := r.periodStart(start, k)
+	var dates []time.Time
+
+	switch r.Freq {
+	case "DAILY":
+		if len(r.ByDay) == 0 {
+			return []time.Time{from}
+		}
+		for _, bd := range r.ByDay {
+			if bd.Weekday == from.Weekday() {
+				return []time.Time{from}
+			}
+		}
+		return nil
+
+	case "WEEKLY":
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []rruleDay{{Weekday: start.Weekday()}}
+		}
+		for _, bd := range days {
+			dates = append(dates, from.AddDate(0, 0, (int(bd.Weekday)+6)%7))
+		}
+
+	case "MONTHLY":
+		n := daysIn(from.Year(), from.Month())
+
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, day := range r.ByMonthDay {
+				if day < 0 {
+					day = n + day + 1
+				}
+				if day >= 1 && day <= n {
+					dates = append(dates, from.AddDate(0, 0, day-1))
+				}
+			}
+		case len(r.ByDay) > 0:
+			for _, bd := range r.ByDay {
+				var matching []time.Time
+				for d := from; d.Month() == from.Month(); d = d.AddDate(0, 0, 1) {
+					if d.Weekday() == bd.Weekday {
+						matching = append(matching, d)
+					}
+				}
+				switch {
+				case bd.Ordinal == 0:
+					dates = append(dates, matching...)
+				case bd.Ordinal > 0 && bd.Ordinal <= len(matching):
+					dates = append(dates, matching[bd.Ordinal-1])
+				case bd.Ordinal < 0 && -bd.Ordinal <= len(matching):
+					dates = append(dates, matching[len(matching)+bd.Ordinal])
+				}
+			}
+		default:
+			if start.Day() <= n {
+				dates = append(dates, from.AddDate(0, 0, start.Day()-1))
+			}
+		}
+
+	case "YEARLY":
+		if start.Day() <= daysIn(from.Year(), start.Month()) {
+			dates = append(dates, time.Date(from.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC))
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// occurrences returns the dates the rule produces from start up to and
+// including end. start is also the first possible occurrence, like DTSTART.
+func (r rrule) occurrences(start, end time.Time) []time.Time {
+	var result []time.Time
+	count := 0
+
+	for k := 0; !r.periodStart(start, k).After(end); k++ {
+		for _, d := range r.periodDates(start, k) {
+			if d.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && d.After(r.Until) {
+				return result
+			}
+			count++
+			if (r.Count > 0 && count > r.Count) || d.After(end) {
+				return result
+			}
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// renderRecurringTemplate fills in the placeholders of a content or
+// description template for one occurrence
+func renderRecurringTemplate(tmpl string, date, due time.Time) string {
+	_, week := date.ISOWeek()
+	return strings.NewReplacer(
+		"{{date}}", date.Format("2006-01-02"),
+		"{{due}}", due.Format("2006-01-02"),
+		"{{weekday}}", date.Weekday().String(),
+		"{{week}}", strconv.Itoa(week),
+		"{{month}}", date.Month().String(),
+		"{{year}}", strconv.Itoa(date.Year()),
+	).Replace(tmpl)
+}
+
+// recurringPlan is a rule with its parsed schedule
+type recurringPlan struct {
+	Rule      config.RecurringRule
+	Schedule  rrule
+	Start     time.Time
+	DueOffset int
+}
+
+func planRecurringRule(rule config.RecurringRule) (recurringPlan, error) {
+	plan := recurringPlan{Rule: rule}
+
+	var err error
+	if plan.Schedule, err = parseRRule(rule.RRule); err != nil {
+		return plan, fmt.Errorf("rule '%s': %w", rule.Name, err)
+	}
+	if plan.Start, err = time.Parse("2006-01-02", rule.Start); err != nil {
+		return plan, fmt.Errorf("rule '%s': start must be a date (YYYY-MM-DD)", rule.Name)
+	}
+	if rule.DueOffset != "" {
+		if plan.DueOffset, err = parseDayCount(rule.DueOffset); err != nil {
+			return plan, fmt.Errorf("rule '%s': due_offset: %w", rule.Name, err)
+		}
+	}
+	return plan, nil
+}
+
+// pendingOccurrences returns the occurrences between the last run (or
+// today, for a rule that has never run) and today that have not been issued
+func pendingOccurrences(plan recurringPlan, rs *config.RecurringRuleState, today time.Time) []time.Time {
+	from := today
+	if rs.LastRun != "" {
+		if last, err := time.Parse("2006-01-02", rs.LastRun); err == nil && last.Before(today) {
+			from = last
+		}
+	}
+
+	var pending []time.Time
+	for _, d := range plan.Schedule.occurrences(plan.Start, today) {
+		if d.Before(from) {
+			continue
+		}
+		if _, issued := rs.Issued[d.Format("2006-01-02")]; issued {
+			continue
+		}
+		pending = append(pending, d)
+	}
+	return pending
+}
+
+type RecurringInstanceOutput struct {
+	Rule    string `json:"rule"`
+	Date    string `json:"date"`
+	TodoID  int    `json:"todo_id,omitempty"`
+	Content string `json:"content"`
+	DueOn   string `json:"due_on"`
+}
+
+type RecurringRunOutput struct {
+	Status  string                    `json:"status"`
+	Date    string                    `json:"date"`
+	DryRun  bool                      `json:"dry_run"`
+	Created []RecurringInstanceOutput `json:"created"`
+	Errors  []string                  `json:"errors,omitempty"`
+	Message string                    `json:"message"`
+}
+
+type RecurringRuleOutput struct {
+	Name           string   `json:"name"`
+	Project        string   `json:"project"`
+	Todolist       string   `json:"todolist"`
+	RRule          string   `json:"rrule"`
+	Content        string   `json:"content"`
+	Assignees      []string `json:"assignees,omitempty"`
+	LastRun        string   `json:"last_run,omitempty"`
+	Issued         int      `json:"issued"`
+	NextOccurrence string   `json:"next_occurrence,omitempty"`
+}
+
+func (c *RecurringCmd) Run(args []string) error {
+	usage := errors.New("usage: basecamp recurring <run|list> [--rules <file>] [--state <file>] [--dry-run]")
+	if len(args) < 1 {
+		return usage
+	}
+	sub := args[0]
+
+	rulesFile := config.RecurringFile()
+	stateFile := config.RecurringStateFile()
+	var dryRun bool
+
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--rules":
+			if i+1 < len(args) {
+				rulesFile = args[i+1]
+				i++
+			}
+		case "--state":
+			if i+1 < len(args) {
+				stateFile = args[i+1]
+				i++
+			}
+		case "--dry-run":
+			dryRun = true
+		}
+	}
+
+	rules, err := config.LoadRecurringRules(rulesFile)
+	if err != nil {
+		return fmt.Errorf("failed to load recurring rules: %w", err)
+	}
+
+	plans := make([]recurringPlan, len(rules))
+	for i, rule := range rules {
+		if plans[i], err = planRecurringRule(rule); err != nil {
+			return err
+		}
+	}
+
+	state, err := config.LoadRecurringState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	switch sub {
+	case "list":
+		return listRecurring(plans, state, today)
+	case "run":
+		return runRecurring(plans, state, stateFile, today, dryRun)
+	}
+	return usage
+}
+
+func listRecurring(plans []recurringPlan, state *config.RecurringState, today time.Time) error {
+	output := make([]RecurringRuleOutput, len(plans))
+	for i, plan := range plans {
+		rs := state.Rule(plan.Rule.Name)
+		output[i] = RecurringRuleOutput{
+			Name:      plan.Rule.Name,
+			Project:   plan.Rule.Project,
+			Todolist:  plan.Rule.Todolist,
+			RRule:     plan.Rule.RRule,
+			Content:   plan.Rule.Content,
+			Assignees: plan.Rule.Assignees,
+			LastRun:   rs.LastRun,
+			Issued:    len(rs.Issued),
+		}
+
+		// Look a few years ahead for the next occurrence not yet issued
+		for _, d := range plan.Schedule.occurrences(plan.Start, today.AddDate(5, 0, 0)) {
+			if _, issued := rs.Issued[d.Format("2006-01-02")]; !issued && !d.Before(today) {
+				output[i].NextOccurrence = d.Format("2006-01-02")
+				break
+			}
+		}
+	}
+	return PrintJSON(output)
+}
+
+// issueRecurring creates the todos for a rule's pending occurrences,
+// recording each in the state file as soon as it exists so that a failure
+// later in the run never creates it twice
+func issueRecurring(cl *client.Client, people *[]Person, plan recurringPlan, pending []time.Time, state *config.RecurringState, stateFile string) ([]RecurringInstanceOutput, error) {
+	rule := plan.Rule
+	rs := state.Rule(rule.Name)
+
+	parentID, err := resolveTodolistID(cl, rule.Project, rule.Todolist)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Group != "" {
+		if parentID, err = resolveTodolistGroupID(cl, rule.Project, parentID, rule.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	var assigneeIDs []int
+	if len(rule.Assignees) > 0 {
+		if *people == nil {
+			if *people, err = fetchPeople(cl); err != nil {
+				return nil, err
+			}
+		}
+		if assigneeIDs, err = resolvePersonIDs(*people, rule.Assignees); err != nil {
+			return nil, err
+		}
+	}
+
+	var created []RecurringInstanceOutput
+	for _, d := range pending {
+		due := d.AddDate(0, 0, plan.DueOffset)
+		payload := map[string]any{
+			"content": renderRecurringTemplate(rule.Content, d, due),
+			"due_on":  due.Format("2006-01-02"),
+		}
+		if rule.Description != "" {
+			payload["description"] = textToHTML(renderRecurringTemplate(rule.Description, d, due))
+		}
+		if len(assigneeIDs) > 0 {
+			payload["assignee_ids"] = assigneeIDs
+		}
+
+		data, err := cl.Post("/buckets/"+rule.Project+"/todolists/"+parentID+"/todos.json", payload)
+		if err != nil {
+			return created, err
+		}
+
+		var todo Todo
+		if err := json.Unmarshal(data, &todo); err != nil {
+			return created, err
+		}
+
+		rs.Issued[d.Format("2006-01-02")] = todo.ID
+		if err := config.SaveRecurringState(stateFile, state); err != nil {
+			return created, err
+		}
+
+		created = append(created, RecurringInstanceOutput{
+			Rule:    rule.Name,
+			Date:    d.Format("2006-01-02"),
+			TodoID:  todo.ID,
+			Content: todo.Content,
+			DueOn:   todo.DueOn,
+		})
+	}
+	return created, nil
+}
+
+func runRecurring(plans []recurringPlan, state *config.RecurringState, stateFile string, today time.Time, dryRun bool) error {
+	output := RecurringRunOutput{
+		Status:  "ok",
+		Date:    today.Format("2006-01-02"),
+		DryRun:  dryRun,
+		Created: []RecurringInstanceOutput{},
+	}
+
+	var cl *client.Client
+	var people []Person
+
+	for _, plan := range plans {
+		rs := state.Rule(plan.Rule.Name)
+		pending := pendingOccurrences(plan, rs, today)
+
+		if dryRun {
+			for _, d := range pending {
+				due := d.AddDate(0, 0, plan.DueOffset)
+				output.Created = append(output.Created, RecurringInstanceOutput{
+					Rule:    plan.Rule.Name,
+					Date:    d.Format("2006-01-02"),
+					Content: renderRecurringTemplate(plan.Rule.Content, d, due),
+					DueOn:   due.Format("2006-01-02"),
+				})
+			}
+			continue
+		}
+
+		if len(pending) > 0 {
+			var err error
+			if cl == nil {
+				if cl, err = client.New(); err != nil {
+					return err
+				}
+			}
+
+			created, err := issueRecurring(cl, &people, plan, pending, state, stateFile)
+			output.Created = append(output.Created, created...)
+			if err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", plan.Rule.Name, err))
+				continue
+			}
+		}
+		rs.LastRun = output.Date
+	}
+
+	verb := "created"
+	if dryRun {
+		verb = "would be created"
+	} else if err := config.SaveRecurringState(stateFile, state); err != nil {
+		return err
+	}
+	output.Message = fmt.Sprintf("%d recurring todos %s", len(output.Created), verb)
+
+	if len(output.Errors) > 0 {
+		output.Status = "error"
+		if err := PrintJSON(output); err != nil {
+			return err
+		}
+		return fmt.Errorf("%d recurring rules failed", len(output.Errors))
+	}
+	return PrintJSON(output)
+}
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/config"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, len(dates))
+	for i, d := range dates {
+		out[i] = d.Format("2006-01-02")
+	}
+	return out
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=9MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;UNTIL=soon",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ",
+	} {
+		if _, err := parseRRule(s); err == nil {
+			t.Errorf("parseRRule(%q) expected error", s)
+		}
+	}
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		end   string
+		want  []string
+	}{
+		{
+			name:  "daily interval",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: "2026-01-01",
+			end:   "2026-01-10",
+			want:  []string{"2026-01-01", "2026-01-04", "2026-01-07", "2026-01-10"},
+		},
+		{
+			name:  "weekdays only",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: "2026-01-02",
+			end:   "2026-01-06",
+			want:  []string{"2026-01-02", "2026-01-05", "2026-01-06"},
+		},
+		{
+			name:  "weekly defaults to start weekday",
+			rule:  "RRULE:FREQ=WEEKLY",
+			start: "2026-01-05",
+			end:   "2026-01-20",
+			want:  []string{"2026-01-05", "2026-01-12", "2026-01-19"},
+		},
+		{
+			name:  "every other week on two days",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,MO",
+			start: "2026-01-06",
+			end:   "2026-01-31",
+			want:  []string{"2026-01-08", "2026-01-19", "2026-01-22"},
+		},
+		{
+			name:  "monthly by day of month skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: "2026-01-31",
+			end:   "2026-05-31",
+			want:  []string{"2026-01-31", "2026-03-31", "2026-05-31"},
+		},
+		{
+			name:  "last day of month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: "2026-01-01",
+			end:   "2026-03-31",
+			want:  []string{"2026-01-31", "2026-02-28", "2026-03-31"},
+		},
+		{
+			name:  "first monday and last friday",
+			rule:  "FREQ=MONTHLY;BYDAY=1MO,-1FR",
+			start: "2026-01-01",
+			end:   "2026-02-28",
+			want:  []string{"2026-01-05", "2026-01-30", "2026-02-02", "2026-02-27"},
+		},
+		{
+			name:  "yearly leap day",
+			rule:  "FREQ=YEARLY",
+			start: "2024-02-29",
+			end:   "2029-01-01",
+			want:  []string{"2024-02-29", "2028-02-29"},
+		},
+		{
+			name:  "count",
+			rule:  "FREQ=WEEKLY;COUNT=2",
+			start: "2026-01-05",
+			end:   "2026-12-31",
+			want:  []string{"2026-01-05", "2026-01-12"},
+		},
+		{
+			name:  "until",
+			rule:  "FREQ=DAILY;UNTIL=20260103T000000Z",
+			start: "2026-01-01",
+			end:   "2026-12-31",
+			want:  []string{"2026-01-01", "2026-01-02", "2026-01-03"},
+		},
+		{
+			name:  "end before start",
+			rule:  "FREQ=DAILY",
+			start: "2026-01-10",
+			end:   "2026-01-01",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule(%q) error = %v", tt.rule, err)
+			}
+			got := formatDates(r.occurrences(day(tt.start), day(tt.end)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPendingOccurrences(t *testing.T) {
+	plan, err := planRecurringRule(config.RecurringRule{
+		Name:      "daily",
+		RRule:     "FREQ=DAILY",
+		Start:     "2026-01-01",
+		DueOffset: "1d",
+	})
+	if err != nil {
+		t.Fatalf("planRecurringRule() error = %v", err)
+	}
+	if plan.DueOffset != 1 {
+		t.Errorf("DueOffset = %d, want 1", plan.DueOffset)
+	}
+
+	// A rule that has never run only issues today's occurrence
+	rs := &config.RecurringRuleState{Issued: map[string]int{}}
+	if got := formatDates(pendingOccurrences(plan, rs, day("2026-01-10"))); !reflect.DeepEqual(got, []string{"2026-01-10"}) {
+		t.Errorf("first run = %v", got)
+	}
+
+	// Later runs catch up from the last run, skipping issued dates
+	rs = &config.RecurringRuleState{LastRun: "2026-01-07", Issued: map[string]int{"2026-01-07": 1}}
+	want := []string{"2026-01-08", "2026-01-09", "2026-01-10"}
+	if got := formatDates(pendingOccurrences(plan, rs, day("2026-01-10"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("catch up = %v, want %v", got, want)
+	}
+
+	// Re-running on the same day issues nothing new
+	rs = &config.RecurringRuleState{LastRun: "2026-01-10", Issued: map[string]int{"2026-01-10": 2}}
+	if got := pendingOccurrences(plan, rs, day("2026-01-10")); len(got) != 0 {
+		t.Errorf("re-run = %v, want none", formatDates(got))
+	}
+}
+
+func TestPlanRecurringRuleErrors(t *testing.T) {
+	base := config.RecurringRule{Name: "r", RRule: "FREQ=DAILY", Start: "2026-01-01"}
+
+	bad := base
+	bad.RRule = "FREQ=SOMETIMES"
+	if _, err := planRecurringRule(bad); err == nil {
+		t.Error("expected error for invalid rrule")
+	}
+
+	bad = base
+	bad.Start = "January"
+	if _, err := planRecurringRule(bad); err == nil {
+		t.Error("expected error for invalid start")
+	}
+
+	bad = base
+	bad.DueOffset = "later"
+	if _, err := planRecurringRule(bad); err == nil {
+		t.Error("expected error for invalid due_offset")
+	}
+}
+
+func TestRenderRecurringTemplate(t *testing.T) {
+	got := renderRecurringTemplate("{{weekday}} review, week {{week}} of {{year}} ({{month}} {{date}}, due {{due}})", day("2026-01-05"), day("2026-01-07"))
+	want := "Monday review, week 2 of 2026 (January 2026-01-05, due 2026-01-07)"
+	if got != want {
+		t.Errorf("renderRecurringTemplate() = %q, want %q", got, want)
+	}
+}
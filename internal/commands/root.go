@@ -22,33 +22,62 @@ var commands = map[string]func() Command{
 	"cards":                 func() Command { return &CardsCmd{} },
 	"card":                  func() Command { return &CardCmd{} },
 	"move":                  func() Command { return &MoveCmd{} },
+	"board-snapshot":        func() Command { return &BoardSnapshotCmd{} },
+	"board-diff":            func() Command { return &BoardDiffCmd{} },
+	"board-metrics":         func() Command { return &BoardMetricsCmd{} },
 	"todolists":             func() Command { return &TodolistsCmd{} },
+	"todolist-create":       func() Command { return &TodolistCreateCmd{} },
+	"todolist-update":       func() Command { return &TodolistUpdateCmd{} },
 	"todos":                 func() Command { return &TodosCmd{} },
 	"todo":                  func() Command { return &TodoCmd{} },
 	"todo-create":           func() Command { return &TodoCreateCmd{} },
+	"todo-update":           func() Command { return &TodoUpdateCmd{} },
+	"todo-trash":            func() Command { return &TodoTrashCmd{} },
 	"todo-complete":         func() Command { return &TodoCompleteCmd{} },
 	"todo-uncomplete":       func() Command { return &TodoUncompleteCmd{} },
 	"messages":              func() Command { return &MessagesCmd{} },
 	"message":               func() Command { return &MessageCmd{} },
 	"message-create":        func() Command { return &MessageCreateCmd{} },
+	"message-update":        func() Command { return &MessageUpdateCmd{} },
+	"message-pin":           func() Command { return &MessagePinCmd{} },
+	"message-unpin":         func() Command { return &MessageUnpinCmd{} },
 	"comment-add":           func() Command { return &CommentAddCmd{} },
+	"comments":              func() Command { return &CommentsCmd{} },
+	"comment":               func() Command { return &CommentCmd{} },
+	"comment-update":        func() Command { return &CommentUpdateCmd{} },
+	"comment-trash":         func() Command { return &CommentTrashCmd{} },
 	"docs":                  func() Command { return &DocsCmd{} },
 	"doc":                   func() Command { return &DocCmd{} },
 	"doc-create":            func() Command { return &DocCreateCmd{} },
 	"schedule":              func() Command { return &ScheduleCmd{} },
 	"event":                 func() Command { return &EventCmd{} },
 	"event-create":          func() Command { return &EventCreateCmd{} },
+	"event-update":          func() Command { return &EventUpdateCmd{} },
+	"event-trash":           func() Command { return &EventTrashCmd{} },
+	"schedule-export":       func() Command { return &ScheduleExportCmd{} },
+	"schedule-import":       func() Command { return &ScheduleImportCmd{} },
 	"campfire":              func() Command { return &CampfireCmd{} },
 	"campfire-post":         func() Command { return &CampfirePostCmd{} },
+	"campfire-line":         func() Command { return &CampfireLineCmd{} },
+	"campfire-delete":       func() Command { return &CampfireDeleteCmd{} },
+	"chatbots":              func() Command { return &ChatbotsCmd{} },
+	"chatbot-create":        func() Command { return &ChatbotCreateCmd{} },
+	"chatbot-update":        func() Command { return &ChatbotUpdateCmd{} },
+	"chatbot-delete":        func() Command { return &ChatbotDeleteCmd{} },
+	"chatbot-save":          func() Command { return &ChatbotSaveCmd{} },
+	"chatbot-post":          func() Command { return &ChatbotPostCmd{} },
 	"columns":               func() Command { return &ColumnsCmd{} },
 	"card-create":           func() Command { return &CardCreateCmd{} },
 	"card-update":           func() Command { return &CardUpdateCmd{} },
 	"search":                func() Command { return &SearchCmd{} },
+	"steps":                 func() Command { return &StepsCmd{} },
 	"step-create":           func() Command { return &StepCreateCmd{} },
 	"step-update":           func() Command { return &StepUpdateCmd{} },
 	"step-complete":         func() Command { return &StepCompleteCmd{} },
 	"step-uncomplete":       func() Command { return &StepUncompleteCmd{} },
+	"step-delete":           func() Command { return &StepDeleteCmd{} },
 	"step-reposition":       func() Command { return &StepRepositionCmd{} },
+	"step-import":           func() Command { return &StepImportCmd{} },
 	"people":                func() Command { return &PeopleCmd{} },
 	"person":                func() Command { return &PersonCmd{} },
 	"people-pingable":       func() Command { return &PeoplePingableCmd{} },
@@ -64,9 +93,20 @@ var commands = map[string]func() Command{
 	"todolist-group":        func() Command { return &TodolistGroupCmd{} },
 	"todolist-group-create": func() Command { return &TodolistGroupCreateCmd{} },
 	"todo-reposition":       func() Command { return &TodoRepositionCmd{} },
+	"todo-move":             func() Command { return &TodoMoveCmd{} },
+	"todos-import":          func() Command { return &TodosImportCmd{} },
+	"todos-export":          func() Command { return &TodosExportCmd{} },
+	"recurring":             func() Command { return &RecurringCmd{} },
+	"my-todos":              func() Command { return &MyTodosCmd{} },
+	"assigned":              func() Command { return &AssignedCmd{} },
 	"upload":                func() Command { return &UploadCmd{} },
 	"uploads":               func() Command { return &UploadsCmd{} },
 	"upload-view":           func() Command { return &UploadViewCmd{} },
+	"upload-create":         func() Command { return &UploadCreateCmd{} },
+	"upload-update":         func() Command { return &UploadUpdateCmd{} },
+	"upload-trash":          func() Command { return &UploadTrashCmd{} },
+	"vaults":                func() Command { return &VaultsCmd{} },
+	"vault-create":          func() Command { return &VaultCreateCmd{} },
 	"archive":               func() Command { return &ArchiveCmd{} },
 	"unarchive":             func() Command { return &UnarchiveCmd{} },
 	"trash":                 func() Command { return &TrashCmd{} },
@@ -124,40 +164,82 @@ Commands:
 Card Tables:
   boards [project_id]               List card tables in a project
   columns [project_id] <board_id>   List columns in a board
-  cards [project_id] <board_id>     List cards (--column <name> to filter)
+  cards [project_id] <board_id>     List cards (--column, --assignee, --due-before,
+                                    --overdue, --mine, --unassigned, --sort)
   card [project_id] <card_id>       View card details (--comments for comments)
   card-create [project_id] <board>  Create card (--column, --title required)
   card-update [project_id] <card>   Update card (--title, --content, --due)
   move [project_id] <board> <card>  Move card (--to <column> required)
+  board-snapshot [project_id] <board> Save board state as JSON (--out <file>)
+  board-diff <old.json> <new.json|live> Compare snapshots (--summary for text)
+  board-metrics [project_id] <board> Flow metrics (--since, --start, --done,
+                                    --csv <dir>)
 
 Card Steps:
+  steps [project_id] <card>         List steps with completion summary
+                                    (--completed or --pending to filter)
   step-create [project_id] <card>   Create step (--title required)
   step-update [project_id] <step>   Update step (--title, --due, --assignees)
   step-complete [project_id] <step> Mark step as complete
   step-uncomplete [project_id] <step> Mark step as incomplete
+  step-delete [project_id] <step>   Delete a step
   step-reposition [project_id] <card> <step> Reposition step (--position required)
+  step-import [project_id] <card>   Create steps from a markdown checklist
+                                    (--file <path> or --template <name>)
 
 Todos:
   todolists [project_id]            List todo lists
+  todolist-create [project_id]      Create todo list (--name required; --description)
+  todolist-update [project_id] <list> Update todo list (--name, --description)
   todos [project_id] <todolist_id>  List todos (--completed for completed)
-  todo [project_id] <todo_id>       View todo details
-  todo-create [project_id] <list>   Create todo (--content required)
+  todo [project_id] <todo_id>       View todo details (--body)
+  todo-create [project_id] <list>   Create todo (--content required; --due, --starts,
+                                    --assignees, --subscribers, --notify)
+  todo-update [project_id] <id>     Update todo (--content, --due, --starts, --assignees,
+                                    --subscribers, --clear-due, --clear-assignees, ...)
+  todo-trash [project_id] <id>      Move todo to trash
   todo-complete [project_id] <id>   Mark todo as complete
   todo-uncomplete [project_id] <id> Mark todo as incomplete
   todo-reposition [project_id] <id> Reposition todo (--position required)
+  todo-move [project_id] <id>       Move todo to another list (--to-list required;
+                                    --group, --position; lists/groups by ID or name)
+  todos-import [project_id] <list>  Import todos from markdown or CSV (--file required;
+                                    --map field=Header, --dry-run, --resume; --all
+                                    instead of <list> for '# Todolist' headings)
+  todos-export [project_id] <list>  Export todos (--all for every list;
+                                    --format md|csv|ics, --out <file>)
+  recurring run                     Create recurring todos due since the last run
+                                    (--dry-run, --rules <file>, --state <file>)
+  recurring list                    List recurring rules and their next occurrence
 
 Todo Groups:
   todolist-groups [project_id] <list> List groups in a todolist
   todolist-group [project_id] <id>    View group details
   todolist-group-create [project_id] <list> Create group (--name required)
 
+Assignments (all projects):
+  my-todos                          Open todos and card steps assigned to you
+                                    (--overdue, --due-within 7d, --include-completed)
+  assigned --person <id|name>       Same, for another person
+
 Messages:
   messages [project_id]             List messages
   message [project_id] <message_id> View message (--comments for comments)
-  message-create [project_id]       Create message (--subject required)
+  message-create [project_id]       Create message (--subject required; --category,
+                                    --status drafted|active, --subscribers)
+  message-update [project_id] <id>  Update message (--subject, --content, --category)
+  message-pin [project_id] <id>     Pin message to the top of the board
+  message-unpin [project_id] <id>   Unpin message
 
 Comments:
-  comment-add [project_id] <id>     Add comment to recording (--content required)
+  comment-add [project_id] <id>     Add comment to recording (--content,
+                                    --content-file <file|->, --stdin; --markdown)
+  comments [project_id] <id>        List comments on a recording (--since <time>,
+                                    --page <n>, --body)
+  comment [project_id] <comment_id> View comment (--body)
+  comment-update [project_id] <id>  Replace comment content (--content, --content-file,
+                                    --stdin; --markdown)
+  comment-trash [project_id] <id>   Move comment to trash
 
 Documents:
   docs [project_id]                 List documents
@@ -165,13 +247,39 @@ Documents:
   doc-create [project_id]           Create document (--title required)
 
 Schedule:
-  schedule [project_id]             List schedule entries
+  schedule [project_id]             List schedule entries (--from, --to, --upcoming N,
+                                    --participant, --archived, --all-projects)
   event [project_id] <entry_id>     View event (--comments for comments)
-  event-create [project_id]         Create event (--summary, --starts-at, --ends-at)
+  event-create [project_id]         Create event (--summary, --starts-at, --ends-at;
+                                    --participants, --notify, --repeat weekly ...)
+  event-update [project_id] <id>    Update event (--summary, --starts-at, --ends-at,
+                                    --participants, --repeat ..., --no-repeat)
+  event-trash [project_id] <id>     Move event to trash
+  schedule-export [project_id]      Export events as iCalendar (--ics <file>,
+                                    --include-todos, --include-cards)
+  schedule-import [project_id] <f>  Create or update events from an .ics file
+                                    (--dry-run)
 
 Campfire:
-  campfire [project_id]             List campfire messages
-  campfire-post [project_id]        Post to campfire (--content required)
+  campfire [project_id]             List campfire messages (--since <line_id|time>,
+                                    --follow to stream new lines as NDJSON)
+  campfire-post [project_id]        Post to campfire (--content, --content-file,
+                                    --stdin and/or --attach <path>, repeatable;
+                                    --file is an alias; --markdown)
+  campfire-line [project_id] <id>   View campfire line
+  campfire-delete [project_id] <id> Delete campfire line
+
+Chatbots:
+  chatbots [project_id]             List chatbots (with their line URLs)
+  chatbot-create [project_id]       Create chatbot (--name required; --command-url,
+                                    --save <name> to store its URL in config)
+  chatbot-update [project_id] <id>  Update chatbot (--name, --command-url)
+  chatbot-delete [project_id] <id>  Delete chatbot
+  chatbot-save [project_id] <bot>   Store a chatbot URL in config (--as <name>,
+                                    or --url <lines_url> --as <name>)
+  chatbot-post                      Post as a chatbot without auth (--bot <name>
+                                    or --bot-url <url>; --content, --content-file
+                                    or --stdin; --markdown)
 
 Search:
   search <query>                    Search across all projects
@@ -193,9 +301,20 @@ Automatic Check-ins:
   question-answer [project_id] <id> View answer (--comments for comments)
 
 Uploads:
-  upload <file>                     Upload a file (returns attachable_sgid)
-  uploads [project_id] <vault_id>   List uploads in a vault
+  upload <file>                     Upload a file (returns attachable_sgid; streamed,
+                                    with progress on stderr in a terminal)
+  uploads [project_id] [vault]      List uploads in a folder (default: top level)
   upload-view [project_id] <id>     View upload (--comments for comments)
+  upload-create [project_id] <vault> <file> Store a file in a folder
+                                    (--description, --name, --markdown)
+  upload-update [project_id] <id>   Update upload (--description, --name)
+  upload-trash [project_id] <id>    Move upload to trash
+  vaults [project_id]               List folders (--parent <vault> for subfolders)
+  vault-create [project_id] <name>  Create folder (--parent <vault>, or Parent/Name)
+
+  Folders are given by ID or by path from the top level, like Design/Logos.
+  A top-level folder named like a number, such as 2024, is matched by name
+  before the number is tried as an ID.
 
 Recordings:
   archive [project_id] <id>         Archive a recording
@@ -219,6 +338,20 @@ Activity Events:
 Project ID can be omitted if .basecamp.yml exists in current or parent directory:
   project_id: 12345678
 
+Rich text (--content on message, doc, card, comment, campfire-post and chatbot-post,
+todo --description):
+  --markdown converts markdown to Basecamp HTML; set "markdown": true in
+  config.json to make it the default and use --no-markdown to send raw HTML.
+  --content-file <file|-> and --stdin read the content from a file or stdin.
+  @Jane, @Jane Doe and @jane@example.com in --content (and campfire-post)
+  mention people; unknown or ambiguous names are errors and nothing is posted.
+  --no-mentions keeps them as typed. Content read from stdin, such as piped
+  logs, is left as typed unless --mentions is given.
+  --attach <path> (repeatable) on message-create, doc-create, card-create,
+  comment-add and campfire-post uploads a file and embeds it in the content.
+  --body markdown|text|html on message, doc, card, todo and comment views picks
+  how content is shown: markdown (default), text (one line) or raw html.
+
 Examples:
   basecamp projects
   basecamp boards
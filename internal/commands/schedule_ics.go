@@ -0,0 +1,653 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+type ScheduleExportCmd struct{}
+
+type ScheduleExportOutput struct {
+	Status  string `json:"status"`
+	File    string `json:"file"`
+	Events  int    `json:"events"`
+	Todos   int    `json:"todos"`
+	Cards   int    `json:"cards"`
+	Message string `json:"message"`
+}
+
+// icsUIDMarkerRegex finds the UID that schedule-import keeps in the
+// description of entries it created from another calendar
+var icsUIDMarkerRegex = regexp.MustCompile(`(?m)^iCalendar UID: (\S+)$`)
+
+const scheduleEntryUIDPrefix = "basecamp-schedule-entry-"
+
+// icsUIDMarker is appended to the description of imported entries
+func icsUIDMarker(uid string) string {
+	return "<div>iCalendar UID: " + html.EscapeString(uid) + "</div>"
+}
+
+// scheduleEntryUID returns the UID an entry is exported with: the UID it
+// was imported with, or one derived from its ID
+func scheduleEntryUID(e ScheduleEntry) string {
+	if m := icsUIDMarkerRegex.FindStringSubmatch(htmlToText(e.Description)); m != nil {
+		return m[1]
+	}
+	return scheduleEntryUIDPrefix + strconv.Itoa(e.ID)
+}
+
+// scheduleEntryText returns the description as plain text, without the
+// import marker
+func scheduleEntryText(e ScheduleEntry) string {
+	return strings.TrimSpace(icsUIDMarkerRegex.ReplaceAllString(htmlToText(e.Description), ""))
+}
+
+func writeICSEvent(b *strings.Builder, e ScheduleEntry, now time.Time) error {
+	start, err := time.Parse(time.RFC3339, e.StartsAt)
+	if err != nil {
+		return fmt.Errorf("entry %d has invalid starts_at '%s'", e.ID, e.StartsAt)
+	}
+	end, err := time.Parse(time.RFC3339, e.EndsAt)
+	if err != nil {
+		return fmt.Errorf("entry %d has invalid ends_at '%s'", e.ID, e.EndsAt)
+	}
+
+	writeICSLine(b, "BEGIN:VEVENT")
+	writeICSLine(b, "UID:"+scheduleEntryUID(e))
+	writeICSLine(b, "DTSTAMP:"+icsTimestamp(e.UpdatedAt, now))
+	if e.AllDay {
+		// DTEND of an all-day event is the day after it ends
+		writeICSLine(b, "DTSTART;VALUE=DATE:"+start.Format(icsDateFormat))
+		writeICSLine(b, "DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format(icsDateFormat))
+	} else {
+		writeICSLine(b, "DTSTART:"+start.UTC().Format(icsTimestampFormat))
+		writeICSLine(b, "DTEND:"+end.UTC().Format(icsTimestampFormat))
+	}
+	writeICSLine(b, "SUMMARY:"+icsEscape(e.Summary))
+	if text := scheduleEntryText(e); text != "" {
+		writeICSLine(b, "DESCRIPTION:"+icsEscape(text))
+	}
+	if e.RecurrenceSchedule != nil {
+		rr, err := recurrenceRRule(*e.RecurrenceSchedule)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", e.ID, err)
+		}
+		writeICSLine(b, "RRULE:"+rr.String())
+	}
+	if e.URL != "" {
+		writeICSLine(b, "URL:"+e.URL)
+	}
+	writeICSLine(b, "END:VEVENT")
+	return nil
+}
+
+func writeICSCard(b *strings.Builder, card CardSummary, category string, now time.Time) {
+	writeICSLine(b, "BEGIN:VTODO")
+	writeICSLine(b, fmt.Sprintf("UID:basecamp-card-%d", card.ID))
+	writeICSLine(b, "DTSTAMP:"+icsTimestamp(card.UpdatedAt, now))
+	writeICSLine(b, "SUMMARY:"+icsEscape(card.Title))
+	if len(card.Assignees) > 0 {
+		names := make([]string, len(card.Assignees))
+		for i, a := range card.Assignees {
+			names[i] = a.Name
+		}
+		writeICSLine(b, "DESCRIPTION:"+icsEscape("Assigned to: "+strings.Join(names, ", ")))
+	}
+	writeICSLine(b, "DUE;VALUE=DATE:"+icsDate(card.DueOn))
+	writeICSLine(b, "STATUS:NEEDS-ACTION")
+	writeICSLine(b, "CATEGORIES:"+icsEscape(category))
+	if card.AppURL != "" {
+		writeICSLine(b, "URL:"+card.AppURL)
+	}
+	writeICSLine(b, "END:VTODO")
+}
+
+// scheduleCards is the open cards with due dates on one card table
+type scheduleCards struct {
+	Board string
+	Cards []CardSummary
+}
+
+// renderScheduleICS renders schedule entries as VEVENTs and the given
+// todos and cards as VTODOs. UIDs come from recording IDs so calendar
+// clients update rather than duplicate them on every export.
+func renderScheduleICS(entries []ScheduleEntry, todos []exportTodolist, cards []scheduleCards, now time.Time) (string, error) {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//basecamp-cli//schedule-export//EN")
+
+	for _, e := range entries {
+		if err := writeICSEvent(&b, e, now); err != nil {
+			return "", err
+		}
+	}
+	for _, list := range todos {
+		for _, todo := range list.Todos {
+			writeICSTodo(&b, todo, list.Todolist.Title, now)
+		}
+		for _, group := range list.Groups {
+			for _, todo := range group.Todos {
+				writeICSTodo(&b, todo, list.Todolist.Title+" / "+group.Group.Name, now)
+			}
+		}
+	}
+	for _, board := range cards {
+		for _, card := range board.Cards {
+			writeICSCard(&b, card, board.Board, now)
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String(), nil
+}
+
+// dueTodos keeps the open todos that have a due date
+func dueTodos(todos []Todo) []Todo {
+	var due []Todo
+	for _, todo := range todos {
+		if !todo.Completed && todo.DueOn != "" {
+			due = append(due, todo)
+		}
+	}
+	return due
+}
+
+func fetchScheduleTodos(cl *client.Client, projectID string) ([]exportTodolist, int, error) {
+	_, todolists, err := fetchTodolists(cl, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var lists []exportTodolist
+	total := 0
+	for _, todolist := range todolists {
+		list, err := fetchTodolistExport(cl, projectID, todolist)
+		if err != nil {
+			return nil, 0, err
+		}
+		list.Todos = dueTodos(list.Todos)
+		for i := range list.Groups {
+			list.Groups[i].Todos = dueTodos(list.Groups[i].Todos)
+		}
+		lists = append(lists, list)
+		total += list.count()
+	}
+	return lists, total, nil
+}
+
+func fetchScheduleCards(cl *client.Client, project ProjectDetail) ([]scheduleCards, int, error) {
+	var boards []scheduleCards
+	total := 0
+
+	for _, dock := range project.Dock {
+		if dock.Name != "kanban_board" {
+			continue
+		}
+
+		data, err := cl.Get(dock.URL)
+		if err != nil {
+			return nil, 0, err
+		}
+		var cardTable CardTableDetail
+		if err := json.Unmarshal(data, &cardTable); err != nil {
+			return nil, 0, err
+		}
+
+		board := scheduleCards{Board: cardTable.Title}
+		for _, list := range cardTable.Lists {
+			if list.CardsCount == 0 {
+				continue
+			}
+			cardsData, err := cl.GetAll(list.CardsURL)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, cardJSON := range cardsData {
+				var card CardSummary
+				if err := json.Unmarshal(cardJSON, &card); err != nil {
+					return nil, 0, err
+				}
+				if !card.Completed && card.DueOn != "" {
+					board.Cards = append(board.Cards, card)
+				}
+			}
+		}
+		boards = append(boards, board)
+		total += len(board.Cards)
+	}
+	return boards, total, nil
+}
+
+func (c *ScheduleExportCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var out string
+	var includeTodos, includeCards bool
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--ics":
+			if i+1 < len(remaining) {
+				out = remaining[i+1]
+				i++
+			}
+		case "--include-todos":
+			includeTodos = true
+		case "--include-cards":
+			includeCards = true
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	project, schedule, err := fetchSchedule(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := fetchScheduleEntries(cl, schedule.EntriesURL, false)
+	if err != nil {
+		return err
+	}
+
+	var todos []exportTodolist
+	var cards []scheduleCards
+	var todoCount, cardCount int
+	if includeTodos {
+		if todos, todoCount, err = fetchScheduleTodos(cl, projectID); err != nil {
+			return err
+		}
+	}
+	if includeCards {
+		if cards, cardCount, err = fetchScheduleCards(cl, project); err != nil {
+			return err
+		}
+	}
+
+	rendered, err := renderScheduleICS(entries, todos, cards, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Without --ics the calendar itself is the output
+	if out == "" {
+		fmt.Print(rendered)
+		return nil
+	}
+
+	if err := os.WriteFile(out, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return PrintJSON(ScheduleExportOutput{
+		Status:  "ok",
+		File:    out,
+		Events:  len(entries),
+		Todos:   todoCount,
+		Cards:   cardCount,
+		Message: fmt.Sprintf("%d events, %d todos and %d cards written to %s", len(entries), todoCount, cardCount, out),
+	})
+}
+
+type ScheduleImportCmd struct{}
+
+// icsEvent is a VEVENT reduced to what a schedule entry can hold
+type icsEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Recurrence  *RecurrenceSchedule
+	// RecurrenceID is set on a change to one occurrence of a series,
+	// which shares the series' UID
+	RecurrenceID string
+}
+
+type ScheduleImportItem struct {
+	UID     string `json:"uid"`
+	ID      int    `json:"id,omitempty"`
+	Summary string `json:"summary"`
+	Action  string `json:"action"`
+}
+
+type ScheduleImportOutput struct {
+	Status    string               `json:"status"`
+	DryRun    bool                 `json:"dry_run,omitempty"`
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Skipped   int                  `json:"skipped"`
+	Events    []ScheduleImportItem `json:"events"`
+	Warnings  []string             `json:"warnings,omitempty"`
+	Errors    []string             `json:"errors,omitempty"`
+	Message   string               `json:"message"`
+}
+
+// parseICSEvents reads the VEVENTs of an iCalendar file. Floating times
+// are taken to be in loc.
+func parseICSEvents(text string, loc *time.Location) ([]icsEvent, error) {
+	components, err := parseICSComponents(text, "VEVENT")
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]icsEvent, 0, len(components))
+	for _, c := range components {
+		var e icsEvent
+		if p, ok := c.property("UID"); ok {
+			e.UID = strings.TrimSpace(p.Value)
+		}
+		if e.UID == "" {
+			return nil, errors.New("event without UID")
+		}
+		if p, ok := c.property("SUMMARY"); ok {
+			e.Summary = icsUnescape(p.Value)
+		}
+		if p, ok := c.property("DESCRIPTION"); ok {
+			e.Description = icsUnescape(p.Value)
+		}
+		if p, ok := c.property("RECURRENCE-ID"); ok {
+			e.RecurrenceID = strings.TrimSpace(p.Value)
+		}
+
+		p, ok := c.property("DTSTART")
+		if !ok {
+			return nil, fmt.Errorf("event %s has no DTSTART", e.UID)
+		}
+		if e.Start, e.AllDay, err = parseICSTime(p, loc); err != nil {
+			return nil, fmt.Errorf("event %s: %w", e.UID, err)
+		}
+
+		if p, ok := c.property("DTEND"); ok {
+			if e.End, _, err = parseICSTime(p, loc); err != nil {
+				return nil, fmt.Errorf("event %s: %w", e.UID, err)
+			}
+		} else if p, ok := c.property("DURATION"); ok {
+			d, err := parseICSDuration(p.Value)
+			if err != nil {
+				return nil, fmt.Errorf("event %s: %w", e.UID, err)
+			}
+			e.End = e.Start.Add(d)
+		} else if e.AllDay {
+			e.End = e.Start.AddDate(0, 0, 1)
+		} else {
+			e.End = e.Start
+		}
+
+		// Schedule entries end on their last day, not the day after
+		if e.AllDay && e.End.After(e.Start) {
+			e.End = e.End.AddDate(0, 0, -1)
+		}
+
+		if p, ok := c.property("RRULE"); ok {
+			rr, err := parseRRule(p.Value)
+			if err != nil {
+				return nil, fmt.Errorf("event %s: %w", e.UID, err)
+			}
+			if e.Recurrence, err = rruleRecurrence(rr, e.Start); err != nil {
+				return nil, fmt.Errorf("event %s: %w", e.UID, err)
+			}
+		}
+
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+var icsDurationRegex = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses an iCalendar DURATION like PT1H30M or P1D
+func parseICSDuration(s string) (time.Duration, error) {
+	m := icsDurationRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || s == "P" {
+		return 0, fmt.Errorf("invalid DURATION '%s'", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] != "" {
+			n, _ := strconv.Atoi(m[i+2])
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// description returns the entry description for an imported event. Events
+// that did not come from this schedule keep their UID in a marker line so
+// later imports find them again.
+func (e icsEvent) description(marked bool) string {
+	description := textToHTML(e.Description)
+	if !marked {
+		return description
+	}
+	if description != "" {
+		description += "<br>"
+	}
+	return description + icsUIDMarker(e.UID)
+}
+
+// sameRecurrence reports whether two recurrence schedules repeat the same way
+func sameRecurrence(a, b *RecurrenceSchedule) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ra, errA := recurrenceRRule(*a)
+	rb, errB := recurrenceRRule(*b)
+	return errA == nil && errB == nil && ra.String() == rb.String()
+}
+
+// unchanged reports whether entry already matches the event
+func (e icsEvent) unchanged(entry ScheduleEntry, description string) bool {
+	start, errStart := time.Parse(time.RFC3339, entry.StartsAt)
+	end, errEnd := time.Parse(time.RFC3339, entry.EndsAt)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+	if entry.AllDay != e.AllDay || entry.Summary != e.Summary {
+		return false
+	}
+	if htmlToText(entry.Description) != htmlToText(description) {
+		return false
+	}
+	if e.AllDay {
+		if start.Format("2006-01-02") != e.Start.Format("2006-01-02") || end.Format("2006-01-02") != e.End.Format("2006-01-02") {
+			return false
+		}
+	} else if !start.Equal(e.Start) || !end.Equal(e.End) {
+		return false
+	}
+	return sameRecurrence(entry.RecurrenceSchedule, e.Recurrence)
+}
+
+// matchImportedEntries indexes the entries of a schedule by the UID they
+// would be exported with, so both exported and imported events match
+func matchImportedEntries(entries []ScheduleEntry) map[string]ScheduleEntry {
+	byUID := make(map[string]ScheduleEntry, len(entries)*2)
+	for _, e := range entries {
+		byUID[scheduleEntryUIDPrefix+strconv.Itoa(e.ID)] = e
+		byUID[scheduleEntryUID(e)] = e
+	}
+	return byUID
+}
+
+// importScheduleEvents creates or updates an entry for each event. Changes
+// to a single occurrence of a series are skipped, since an entry can only
+// repeat its series as a whole.
+func importScheduleEvents(cl *client.Client, projectID, entriesURL string, entries []ScheduleEntry, events []icsEvent, dryRun bool) (ScheduleImportOutput, error) {
+	byUID := matchImportedEntries(entries)
+
+	output := ScheduleImportOutput{
+		Status: "ok",
+		DryRun: dryRun,
+		Events: []ScheduleImportItem{},
+	}
+
+	for _, e := range events {
+		if e.Summary == "" {
+			e.Summary = "(No title)"
+		}
+
+		if e.RecurrenceID != "" {
+			output.Skipped++
+			output.Events = append(output.Events, ScheduleImportItem{UID: e.UID, Summary: e.Summary, Action: "skipped"})
+			output.Warnings = append(output.Warnings, fmt.Sprintf("%s: changes to a single occurrence (RECURRENCE-ID %s) are not supported", e.UID, e.RecurrenceID))
+			continue
+		}
+
+		existing, found := byUID[e.UID]
+		// Entries exported from this schedule are matched by ID and
+		// need no marker
+		marked := !found || e.UID != scheduleEntryUIDPrefix+strconv.Itoa(existing.ID)
+		description := e.description(marked)
+
+		item := ScheduleImportItem{UID: e.UID, ID: existing.ID, Summary: e.Summary}
+
+		switch {
+		case found && e.unchanged(existing, description):
+			item.Action = "unchanged"
+			output.Unchanged++
+			output.Events = append(output.Events, item)
+			continue
+		case found:
+			item.Action = "update"
+		default:
+			item.Action = "create"
+		}
+
+		payload := eventPayload(e.Summary, description, e.Start, e.End, e.AllDay)
+		if e.Recurrence != nil {
+			payload["recurrence_schedule"] = e.Recurrence
+		} else if found && existing.RecurrenceSchedule != nil {
+			payload["recurrence_schedule"] = nil
+		}
+
+		// A later event with the same UID updates this one instead of
+		// creating another entry
+		saved := ScheduleEntry{ID: existing.ID}
+		if !dryRun {
+			var responseData []byte
+			var err error
+			if found {
+				responseData, err = cl.Put("/buckets/"+projectID+"/schedule_entries/"+strconv.Itoa(existing.ID)+".json", payload)
+			} else {
+				responseData, err = cl.Post(entriesURL, payload)
+			}
+			if err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", e.UID, err))
+				continue
+			}
+
+			if err := json.Unmarshal(responseData, &saved); err != nil {
+				return output, err
+			}
+			item.ID = saved.ID
+		}
+		byUID[e.UID] = saved
+
+		if found {
+			output.Updated++
+		} else {
+			output.Created++
+		}
+		output.Events = append(output.Events, item)
+	}
+	return output, nil
+}
+
+func (c *ScheduleImportCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var file string
+	var dryRun bool
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--dry-run":
+			dryRun = true
+		default:
+			if file == "" {
+				file = remaining[i]
+			}
+		}
+	}
+
+	if file == "" {
+		return errors.New("usage: basecamp schedule-import [project_id] <file.ics> [--dry-run]")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	events, err := parseICSEvents(string(data), time.Local)
+	if err != nil {
+		return err
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, schedule, err := fetchSchedule(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	entriesURL := schedule.EntriesURL
+	// Convert from full URL to path
+	if idx := strings.Index(entriesURL, "/buckets/"); idx != -1 {
+		entriesURL = entriesURL[idx:]
+	}
+
+	entries, err := fetchScheduleEntries(cl, entriesURL, false)
+	if err != nil {
+		return err
+	}
+
+	output, err := importScheduleEvents(cl, projectID, entriesURL, entries, events, dryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Imported"
+	if dryRun {
+		verb = "Would import"
+	}
+	output.Message = fmt.Sprintf("%s %d events: %d created, %d updated, %d unchanged, %d skipped", verb, len(events), output.Created, output.Updated, output.Unchanged, output.Skipped)
+	if len(output.Errors) > 0 {
+		output.Status = "error"
+		if err := PrintJSON(output); err != nil {
+			return err
+		}
+		return fmt.Errorf("%d events failed to import", len(output.Errors))
+	}
+	return PrintJSON(output)
+}
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+func TestParseICSEvents(t *testing.T) {
+	text := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:abc@example.com",
+		"SUMMARY:Planning\\, Q3",
+		"DESCRIPTION:Agenda:\\nBudget",
+		"DTSTART:20260105T090000Z",
+		"DURATION:PT1H30M",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:offsite",
+		"SUMMARY:Offsite",
+		"DTSTART;VALUE=DATE:20260210",
+		"DTEND;VALUE=DATE:20260212",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := parseICSEvents(text, time.UTC)
+	if err != nil {
+		t.Fatalf("parseICSEvents() error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	planning := events[0]
+	if planning.Summary != "Planning, Q3" || planning.Description != "Agenda:\nBudget" {
+		t.Errorf("planning text = %q / %q", planning.Summary, planning.Description)
+	}
+	if want := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC); !planning.End.Equal(want) {
+		t.Errorf("planning end = %v, want %v", planning.End, want)
+	}
+	if planning.Recurrence == nil || planning.Recurrence.Frequency != "every_week" {
+		t.Errorf("planning recurrence = %+v", planning.Recurrence)
+	}
+
+	// The exclusive DTEND of an all-day event becomes its last day
+	offsite := events[1]
+	if !offsite.AllDay || offsite.End.Format("2006-01-02") != "2026-02-11" {
+		t.Errorf("offsite = %+v", offsite)
+	}
+
+	if _, err := parseICSEvents("BEGIN:VEVENT\nSUMMARY:x\nDTSTART:20260105T090000Z\nEND:VEVENT\n", time.UTC); err == nil {
+		t.Error("expected an error for an event without UID")
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"-PT15M":  -15 * time.Minute,
+	}
+	for in, want := range tests {
+		got, err := parseICSDuration(in)
+		if err != nil || got != want {
+			t.Errorf("parseICSDuration(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"P", "1H", "PT1X"} {
+		if _, err := parseICSDuration(in); err == nil {
+			t.Errorf("parseICSDuration(%q) expected an error", in)
+		}
+	}
+}
+
+func TestScheduleICSRoundTrip(t *testing.T) {
+	weekly := RecurrenceSchedule{Frequency: "every_week", Days: []int{1, 3}, StartDate: "2026-01-05"}
+	entries := []ScheduleEntry{
+		{ID: 1, Summary: "Standup", StartsAt: "2026-01-05T09:00:00Z", EndsAt: "2026-01-05T09:15:00Z", Description: "<div>Daily sync</div>", RecurrenceSchedule: &weekly},
+		{ID: 2, Summary: "Holiday", StartsAt: "2026-02-10T00:00:00Z", EndsAt: "2026-02-11T00:00:00Z", AllDay: true},
+		{ID: 3, Summary: "Imported", StartsAt: "2026-03-01T12:00:00Z", EndsAt: "2026-03-01T13:00:00Z", Description: "Notes<br>" + icsUIDMarker("abc@example.com")},
+	}
+	todos := []exportTodolist{{
+		Todolist: Todolist{Title: "Launch"},
+		Todos:    []Todo{{ID: 10, Content: "Ship", DueOn: "2026-03-02"}},
+	}}
+	cards := []scheduleCards{{Board: "Board", Cards: []CardSummary{{ID: 20, Title: "Review", DueOn: "2026-03-03"}}}}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rendered, err := renderScheduleICS(entries, todos, cards, now)
+	if err != nil {
+		t.Fatalf("renderScheduleICS() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"UID:basecamp-schedule-entry-1\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n",
+		"DTSTART;VALUE=DATE:20260210\r\nDTEND;VALUE=DATE:20260212\r\n",
+		"UID:abc@example.com\r\n",
+		"UID:basecamp-todo-10\r\n",
+		"UID:basecamp-card-20\r\n",
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("export is missing %q", want)
+		}
+	}
+	if strings.Contains(rendered, "iCalendar UID") {
+		t.Error("export should not include the import marker")
+	}
+
+	events, err := parseICSEvents(rendered, time.UTC)
+	if err != nil {
+		t.Fatalf("parseICSEvents() error: %v", err)
+	}
+	if len(events) != len(entries) {
+		t.Fatalf("got %d events back, want %d", len(events), len(entries))
+	}
+
+	// Every exported event matches the entry it came from, unchanged
+	byUID := matchImportedEntries(entries)
+	for i, e := range events {
+		entry, ok := byUID[e.UID]
+		if !ok || entry.ID != entries[i].ID {
+			t.Errorf("event %s did not match entry %d", e.UID, entries[i].ID)
+			continue
+		}
+		marked := e.UID != scheduleEntryUIDPrefix+"1" && e.UID != scheduleEntryUIDPrefix+"2"
+		if !e.unchanged(entry, e.description(marked)) {
+			t.Errorf("event %s should be unchanged after a round trip", e.UID)
+		}
+	}
+}
+
+func TestImportScheduleEventsSkipsOverrides(t *testing.T) {
+	text := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:series@example.com",
+		"SUMMARY:Standup",
+		"DTSTART:20260105T090000Z",
+		"DURATION:PT15M",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:series@example.com",
+		"RECURRENCE-ID:20260112T090000Z",
+		"SUMMARY:Standup (moved)",
+		"DTSTART:20260112T100000Z",
+		"DURATION:PT15M",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:twice@example.com",
+		"SUMMARY:Review",
+		"DTSTART:20260106T090000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:twice@example.com",
+		"SUMMARY:Review again",
+		"DTSTART:20260106T090000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := parseICSEvents(text, time.UTC)
+	if err != nil {
+		t.Fatalf("parseICSEvents() error: %v", err)
+	}
+	if events[1].RecurrenceID != "20260112T090000Z" {
+		t.Errorf("override RecurrenceID = %q", events[1].RecurrenceID)
+	}
+
+	var requests []string
+	nextID := 100
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		id := nextID
+		if r.Method == http.MethodPut {
+			fmt.Sscanf(path.Base(r.URL.Path), "%d.json", &id)
+		} else {
+			nextID++
+		}
+		fmt.Fprintf(w, `{"id":%d}`, id)
+	}))
+	defer srv.Close()
+
+	cl := client.NewWithBaseURL(srv.URL, "token")
+	output, err := importScheduleEvents(cl, "1", "/buckets/1/schedules/2/entries.json", nil, events, false)
+	if err != nil {
+		t.Fatalf("importScheduleEvents() error: %v", err)
+	}
+
+	// The master is created once, the override is left alone and the
+	// second event with a new UID updates the entry the first created
+	want := []string{
+		"POST /buckets/1/schedules/2/entries.json",
+		"POST /buckets/1/schedules/2/entries.json",
+		"PUT /buckets/1/schedule_entries/101.json",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if output.Created != 2 || output.Updated != 1 || output.Skipped != 1 {
+		t.Errorf("created %d, updated %d, skipped %d", output.Created, output.Updated, output.Skipped)
+	}
+	if len(output.Warnings) != 1 || !strings.Contains(output.Warnings[0], "RECURRENCE-ID") {
+		t.Errorf("warnings = %q", output.Warnings)
+	}
+}
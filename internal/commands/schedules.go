@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -56,6 +59,8 @@ type ScheduleEntry struct {
 	URL           string    `json:"app_url"`
 	Creator       Creator   `json:"creator"`
 	Participants  []Creator `json:"participants"`
+
+	RecurrenceSchedule *RecurrenceSchedule `json:"recurrence_schedule"`
 }
 
 type ScheduleListOutput struct {
@@ -64,18 +69,362 @@ type ScheduleListOutput struct {
 	Entries    []ScheduleEntryBrief `json:"entries"`
 }
 
+type ScheduleAllProjectsOutput struct {
+	From    string               `json:"from,omitempty"`
+	To      string               `json:"to,omitempty"`
+	Entries []ScheduleEntryBrief `json:"entries"`
+	Errors  []string             `json:"errors,omitempty"`
+}
+
 type ScheduleEntryBrief struct {
-	ID       int    `json:"id"`
-	Summary  string `json:"summary"`
-	StartsAt string `json:"starts_at"`
-	EndsAt   string `json:"ends_at"`
-	AllDay   bool   `json:"all_day"`
+	ID          int    `json:"id"`
+	Summary     string `json:"summary"`
+	StartsAt    string `json:"starts_at"`
+	EndsAt      string `json:"ends_at"`
+	AllDay      bool   `json:"all_day"`
+	Recurring   bool   `json:"recurring,omitempty"`
+	ProjectID   int    `json:"project_id,omitempty"`
+	ProjectName string `json:"project_name,omitempty"`
 }
 
-func (c *ScheduleCmd) Run(args []string) error {
-	projectID, _, err := getProjectID(args)
+// scheduleFilter narrows schedule entries to a time window and participant.
+// To is exclusive; zero times leave that side of the window open.
+type scheduleFilter struct {
+	From          time.Time
+	To            time.Time
+	ParticipantID int
+	Upcoming      int
+	// Location is the time zone recurring entries repeat in, so they keep
+	// their time of day across daylight saving changes. When nil, the UTC
+	// offset of each entry's start is used.
+	Location *time.Location
+}
+
+func (f scheduleFilter) active() bool {
+	return !f.From.IsZero() || !f.To.IsZero() || f.ParticipantID != 0 || f.Upcoming > 0
+}
+
+// recurrenceRRule converts a recurrence_schedule to the rrule used for
+// recurring todos
+func recurrenceRRule(r RecurrenceSchedule) (rrule, error) {
+	rr := rrule{Interval: 1}
+
+	switch r.Frequency {
+	case "every_day":
+		rr.Freq = "DAILY"
+	case "every_week":
+		rr.Freq = "WEEKLY"
+		if r.WeekInterval != nil && *r.WeekInterval > 0 {
+			rr.Interval = *r.WeekInterval
+		}
+	case "every_month":
+		rr.Freq = "MONTHLY"
+		if r.MonthInterval != nil && *r.MonthInterval > 0 {
+			rr.Interval = *r.MonthInterval
+		}
+	case "every_year":
+		rr.Freq = "YEARLY"
+	default:
+		return rrule{}, fmt.Errorf("unknown recurrence frequency '%s'", r.Frequency)
+	}
+
+	// Days only narrow weekly events, and monthly ones on the nth weekday
+	if rr.Freq == "WEEKLY" || (rr.Freq == "MONTHLY" && r.WeekInstance != nil) {
+		for _, d := range r.Days {
+			day := rruleDay{Weekday: time.Weekday(d)}
+			if rr.Freq == "MONTHLY" {
+				day.Ordinal = *r.WeekInstance
+			}
+			rr.ByDay = append(rr.ByDay, day)
+		}
+	}
+
+	if r.EndDate != "" {
+		until, err := time.Parse("2006-01-02", r.EndDate)
+		if err != nil {
+			return rrule{}, fmt.Errorf("invalid recurrence end_date '%s'", r.EndDate)
+		}
+		rr.Until = until
+	}
+	return rr, nil
+}
+
+// rruleRecurrence converts an iCalendar rrule to a recurrence_schedule for
+// an event starting at start. Rules Basecamp cannot represent are errors.
+func rruleRecurrence(r rrule, start time.Time) (*RecurrenceSchedule, error) {
+	rs := &RecurrenceSchedule{StartDate: start.Format("2006-01-02")}
+
+	days := func() {
+		for _, bd := range r.ByDay {
+			rs.Days = append(rs.Days, int(bd.Weekday))
+		}
+		if len(rs.Days) == 0 {
+			rs.Days = []int{int(start.Weekday())}
+		}
+	}
+
+	switch r.Freq {
+	case "DAILY":
+		if r.Interval > 1 {
+			return nil, fmt.Errorf("repeating every %d days is not supported", r.Interval)
+		}
+		rs.Frequency = "every_day"
+		if len(r.ByDay) > 0 {
+			// Every weekday and the like
+			rs.Frequency = "every_week"
+			days()
+		}
+	case "WEEKLY":
+		rs.Frequency = "every_week"
+		if r.Interval > 1 {
+			rs.WeekInterval = &r.Interval
+		}
+		days()
+	case "MONTHLY":
+		rs.Frequency = "every_month"
+		if r.Interval > 1 {
+			rs.MonthInterval = &r.Interval
+		}
+		if len(r.ByMonthDay) > 1 || (len(r.ByMonthDay) == 1 && r.ByMonthDay[0] != start.Day()) {
+			return nil, errors.New("monthly repeats are only supported on the start day or the nth weekday")
+		}
+		if len(r.ByDay) > 0 {
+			instance := r.ByDay[0].Ordinal
+			for _, bd := range r.ByDay {
+				if bd.Ordinal != instance || bd.Ordinal == 0 || bd.Ordinal < -1 || bd.Ordinal > 4 {
+					return nil, errors.New("monthly repeats are only supported on the 1st-4th or last weekday")
+				}
+			}
+			rs.WeekInstance = &instance
+			days()
+		}
+	case "YEARLY":
+		if r.Interval > 1 {
+			return nil, fmt.Errorf("repeating every %d years is not supported", r.Interval)
+		}
+		rs.Frequency = "every_year"
+	default:
+		return nil, fmt.Errorf("unsupported frequency '%s'", r.Freq)
+	}
+
+	switch {
+	case !r.Until.IsZero():
+		rs.EndDate = r.Until.Format("2006-01-02")
+	case r.Count > 0:
+		// Basecamp only knows end dates, so end on the last occurrence
+		from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		dates := r.occurrences(from, from.AddDate(100, 0, 0))
+		if len(dates) > 0 {
+			rs.EndDate = dates[len(dates)-1].Format("2006-01-02")
+		}
+	}
+	return rs, nil
+}
+
+// expandScheduleEntry returns the entry, or each occurrence of a recurring
+// entry, that overlaps the filter's window
+func expandScheduleEntry(e ScheduleEntry, f scheduleFilter) ([]ScheduleEntryBrief, error) {
+	start, err := time.Parse(time.RFC3339, e.StartsAt)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("entry %d: invalid starts_at '%s'", e.ID, e.StartsAt)
+	}
+	end, err := time.Parse(time.RFC3339, e.EndsAt)
+	if err != nil {
+		end = start
+	}
+
+	overlaps := func(s, e time.Time) bool {
+		return (f.From.IsZero() || !e.Before(f.From)) && (f.To.IsZero() || s.Before(f.To))
+	}
+	brief := ScheduleEntryBrief{
+		ID:       e.ID,
+		Summary:  e.Summary,
+		StartsAt: e.StartsAt,
+		EndsAt:   e.EndsAt,
+		AllDay:   e.AllDay,
+	}
+
+	if e.RecurrenceSchedule == nil || f.To.IsZero() {
+		if overlaps(start, end) {
+			return []ScheduleEntryBrief{brief}, nil
+		}
+		return nil, nil
+	}
+
+	rr, err := recurrenceRRule(*e.RecurrenceSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("entry %d: %w", e.ID, err)
+	}
+
+	// Occurrences are computed on dates, then given the entry's time of day
+	loc := start.Location()
+	if f.Location != nil {
+		loc = f.Location
+		start = start.In(loc)
+	}
+	date := func(t time.Time) time.Time {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	var result []ScheduleEntryBrief
+	duration := end.Sub(start)
+	for _, d := range rr.occurrences(date(start), date(f.To)) {
+		occStart := time.Date(d.Year(), d.Month(), d.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+		occEnd := occStart.Add(duration)
+		if !overlaps(occStart, occEnd) {
+			continue
+		}
+		occurrence := brief
+		occurrence.StartsAt = occStart.Format(time.RFC3339)
+		occurrence.EndsAt = occEnd.Format(time.RFC3339)
+		occurrence.Recurring = true
+		result = append(result, occurrence)
+	}
+	return result, nil
+}
+
+// filterScheduleEntries applies the filter to entries, expanding recurring
+// ones, and returns them sorted by start
+func filterScheduleEntries(entries []ScheduleEntry, f scheduleFilter) ([]ScheduleEntryBrief, error) {
+	result := []ScheduleEntryBrief{}
+
+	for _, e := range entries {
+		if f.ParticipantID != 0 {
+			found := false
+			for _, p := range e.Participants {
+				if p.ID == f.ParticipantID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+
+		expanded, err := expandScheduleEntry(e, f)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+	}
+
+	sortScheduleEntries(result)
+	if f.Upcoming > 0 && len(result) > f.Upcoming {
+		result = result[:f.Upcoming]
+	}
+	return result, nil
+}
+
+func sortScheduleEntries(entries []ScheduleEntryBrief) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, _ := time.Parse(time.RFC3339, entries[i].StartsAt)
+		b, _ := time.Parse(time.RFC3339, entries[j].StartsAt)
+		return a.Before(b)
+	})
+}
+
+// scheduleLocation returns the time zone of your profile, which recurring
+// entries repeat in, or nil if it cannot be loaded
+func scheduleLocation(cl *client.Client) *time.Location {
+	person, err := fetchMyProfile(cl)
+	if err != nil || person.TimeZone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(person.TimeZone)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// fetchScheduleEntries gets every entry of a schedule, or the archived ones
+func fetchScheduleEntries(cl *client.Client, entriesURL string, archived bool) ([]ScheduleEntry, error) {
+	if archived {
+		entriesURL += "?status=archived"
+	}
+
+	data, err := cl.GetAll(entriesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ScheduleEntry, len(data))
+	for i, entryJSON := range data {
+		if err := json.Unmarshal(entryJSON, &entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (c *ScheduleCmd) Run(args []string) error {
+	var allProjects, archived bool
+	var from, to, upcoming, participant string
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all-projects":
+			allProjects = true
+		case "--archived":
+			archived = true
+		case "--from":
+			if i+1 < len(args) {
+				from = args[i+1]
+				i++
+			}
+		case "--to":
+			if i+1 < len(args) {
+				to = args[i+1]
+				i++
+			}
+		case "--upcoming":
+			if i+1 < len(args) {
+				upcoming = args[i+1]
+				i++
+			}
+		case "--participant":
+			if i+1 < len(args) {
+				participant = args[i+1]
+				i++
+			}
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	now := time.Now()
+	var filter scheduleFilter
+	var err error
+
+	if from != "" {
+		if filter.From, err = parseWindowTime(from, now); err != nil {
+			return fmt.Errorf("--from: %w", err)
+		}
+	}
+	if to != "" {
+		if filter.To, err = parseWindowTime(to, now); err != nil {
+			return fmt.Errorf("--to: %w", err)
+		}
+		// A bare date includes the whole day
+		if h, m, s := filter.To.Clock(); h == 0 && m == 0 && s == 0 {
+			filter.To = filter.To.AddDate(0, 0, 1)
+		}
+	}
+	if upcoming != "" {
+		if filter.Upcoming, err = strconv.Atoi(upcoming); err != nil || filter.Upcoming < 1 {
+			return errors.New("--upcoming must be a positive number")
+		}
+		if filter.From.IsZero() {
+			filter.From = now
+		}
+	}
+
+	// Recurring entries can only be expanded up to some point
+	if (filter.Upcoming > 0 || !filter.From.IsZero()) && filter.To.IsZero() {
+		filter.To = filter.From.AddDate(1, 0, 0)
 	}
 
 	cl, err := client.New()
@@ -83,28 +432,55 @@ func (c *ScheduleCmd) Run(args []string) error {
 		return err
 	}
 
-	project, schedule, err := fetchSchedule(cl, projectID)
+	if !filter.To.IsZero() {
+		filter.Location = scheduleLocation(cl)
+	}
+
+	if participant != "" {
+		people, err := fetchPeople(cl)
+		if err != nil {
+			return err
+		}
+		person, err := resolvePerson(people, participant)
+		if err != nil {
+			return err
+		}
+		filter.ParticipantID = person.ID
+	}
+
+	if allProjects {
+		return scheduleAllProjects(cl, filter, archived)
+	}
+
+	projectID, _, err := getProjectID(rest)
 	if err != nil {
 		return err
 	}
 
-	// Get entries
-	entriesData, err := cl.Get(schedule.EntriesURL)
+	project, schedule, err := fetchSchedule(cl, projectID)
 	if err != nil {
 		return err
 	}
 
-	var entries []ScheduleEntry
-	if err := json.Unmarshal(entriesData, &entries); err != nil {
+	entries, err := fetchScheduleEntries(cl, schedule.EntriesURL, archived)
+	if err != nil {
 		return err
 	}
 
 	output := ScheduleListOutput{
 		ProjectID:  project.ID,
 		ScheduleID: schedule.ID,
-		Entries:    make([]ScheduleEntryBrief, len(entries)),
 	}
 
+	if filter.active() {
+		output.Entries, err = filterScheduleEntries(entries, filter)
+		if err != nil {
+			return err
+		}
+		return PrintJSON(output)
+	}
+
+	output.Entries = make([]ScheduleEntryBrief, len(entries))
 	for i, e := range entries {
 		output.Entries[i] = ScheduleEntryBrief{
 			ID:       e.ID,
@@ -118,6 +494,77 @@ func (c *ScheduleCmd) Run(args []string) error {
 	return PrintJSON(output)
 }
 
+// scheduleAllProjects lists the filtered entries of every project's
+// schedule, fetching projects concurrently
+func scheduleAllProjects(cl *client.Client, filter scheduleFilter, archived bool) error {
+	projectsData, err := cl.GetAll("/projects.json")
+	if err != nil {
+		return err
+	}
+
+	projects := make([]ProjectDetail, len(projectsData))
+	for i, projectJSON := range projectsData {
+		if err := json.Unmarshal(projectJSON, &projects[i]); err != nil {
+			return err
+		}
+	}
+
+	results := make([][]ScheduleEntryBrief, len(projects))
+	errs := make([]error, len(projects))
+	forEachConcurrently(len(projects), assignmentWorkers, func(i int) {
+		scheduleURL, err := getDockURL(projects[i], "schedule")
+		if err != nil {
+			// Projects without a schedule have nothing to list
+			return
+		}
+
+		data, err := cl.Get(scheduleURL)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		var schedule Schedule
+		if err := json.Unmarshal(data, &schedule); err != nil {
+			errs[i] = err
+			return
+		}
+
+		entries, err := fetchScheduleEntries(cl, schedule.EntriesURL, archived)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		results[i], errs[i] = filterScheduleEntries(entries, filter)
+	})
+
+	output := ScheduleAllProjectsOutput{Entries: []ScheduleEntryBrief{}}
+	if !filter.From.IsZero() {
+		output.From = filter.From.Format(time.RFC3339)
+	}
+	if !filter.To.IsZero() {
+		output.To = filter.To.Format(time.RFC3339)
+	}
+
+	for i, project := range projects {
+		if errs[i] != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("%s: %v", project.Name, errs[i]))
+			continue
+		}
+		for _, entry := range results[i] {
+			entry.ProjectID = project.ID
+			entry.ProjectName = project.Name
+			output.Entries = append(output.Entries, entry)
+		}
+	}
+
+	sortScheduleEntries(output.Entries)
+	if filter.Upcoming > 0 && len(output.Entries) > filter.Upcoming {
+		output.Entries = output.Entries[:filter.Upcoming]
+	}
+
+	return PrintJSON(output)
+}
+
 type EventCmd struct{}
 
 type EventDetailOutput struct {
@@ -134,6 +581,8 @@ type EventDetailOutput struct {
 	CommentsCount int             `json:"comments_count"`
 	URL           string          `json:"url"`
 	Comments      []CommentOutput `json:"comments,omitempty"`
+
+	Recurrence *RecurrenceSchedule `json:"recurrence,omitempty"`
 }
 
 func (c *EventCmd) Run(args []string) error {
@@ -192,10 +641,11 @@ func (c *EventCmd) Run(args []string) error {
 		UpdatedAt:     entry.UpdatedAt,
 		CommentsCount: entry.CommentsCount,
 		URL:           entry.URL,
+		Recurrence:    entry.RecurrenceSchedule,
 	}
 
 	if showComments && entry.CommentsURL != "" {
-		comments, err := fetchComments(cl, entry.CommentsURL)
+		comments, err := fetchComments(cl, entry.CommentsURL, bodyText)
 		if err != nil {
 			return err
 		}
@@ -214,72 +664,308 @@ type EventCreateOutput struct {
 	Message string `json:"message"`
 }
 
-func (c *EventCreateCmd) Run(args []string) error {
-	projectID, remaining, err := getProjectID(args)
-	if err != nil {
-		return err
-	}
+// RecurrenceSchedule mirrors the recurrence_schedule of a schedule entry
+type RecurrenceSchedule struct {
+	Frequency     string `json:"frequency"`
+	Days          []int  `json:"days,omitempty"`
+	WeekInstance  *int   `json:"week_instance,omitempty"`
+	WeekInterval  *int   `json:"week_interval,omitempty"`
+	MonthInterval *int   `json:"month_interval,omitempty"`
+	StartDate     string `json:"start_date,omitempty"`
+	EndDate       string `json:"end_date,omitempty"`
+}
 
-	// Parse flags
-	var summary, description, startsAt, endsAt string
-	allDay := false
+var recurrenceFrequencies = map[string]string{
+	"daily":   "every_day",
+	"weekly":  "every_week",
+	"monthly": "every_month",
+	"yearly":  "every_year",
+}
 
-	for i := 0; i < len(remaining); i++ {
-		switch remaining[i] {
-		case "--summary":
-			if i+1 < len(remaining) {
-				summary = remaining[i+1]
+// eventFlags holds the flags shared by event-create and event-update.
+// Pointers are nil when a flag was not given.
+type eventFlags struct {
+	Summary           *string
+	Description       *string
+	StartsAt          *string
+	EndsAt            *string
+	AllDay            *bool
+	Participants      *string
+	ClearParticipants bool
+	Notify            *bool
+
+	Repeat      string
+	RepeatDays  string
+	RepeatEvery string
+	RepeatWeek  string
+	RepeatUntil string
+	NoRepeat    bool
+	Args        []string
+}
+
+func parseEventFlags(args []string) eventFlags {
+	var f eventFlags
+
+	for i := 0; i < len(args); i++ {
+		value := func() *string {
+			if i+1 < len(args) {
 				i++
+				return &args[i]
 			}
+			return nil
+		}
+
+		switch args[i] {
+		case "--summary":
+			f.Summary = value()
 		case "--description":
-			if i+1 < len(remaining) {
-				description = remaining[i+1]
-				i++
-			}
+			f.Description = value()
 		case "--starts-at":
-			if i+1 < len(remaining) {
-				startsAt = remaining[i+1]
-				i++
-			}
+			f.StartsAt = value()
 		case "--ends-at":
-			if i+1 < len(remaining) {
-				endsAt = remaining[i+1]
-				i++
-			}
+			f.EndsAt = value()
 		case "--all-day":
-			allDay = true
+			f.AllDay = boolPtr(true)
+		case "--no-all-day":
+			f.AllDay = boolPtr(false)
+		case "--participants":
+			f.Participants = value()
+		case "--clear-participants":
+			f.ClearParticipants = true
+		case "--notify":
+			f.Notify = boolPtr(true)
+		case "--no-notify":
+			f.Notify = boolPtr(false)
+		case "--repeat":
+			f.Repeat = stringValue(value())
+		case "--repeat-days":
+			f.RepeatDays = stringValue(value())
+		case "--repeat-every":
+			f.RepeatEvery = stringValue(value())
+		case "--repeat-week":
+			f.RepeatWeek = stringValue(value())
+		case "--repeat-until":
+			f.RepeatUntil = stringValue(value())
+		case "--no-repeat":
+			f.NoRepeat = true
+		default:
+			f.Args = append(f.Args, args[i])
+		}
+	}
+
+	return f
+}
+
+func (f eventFlags) hasRecurrence() bool {
+	return f.Repeat != "" || f.RepeatDays != "" || f.RepeatEvery != "" || f.RepeatWeek != "" || f.RepeatUntil != ""
+}
+
+// hasChanges reports whether any flag changes the event; --notify alone
+// does not
+func (f eventFlags) hasChanges() bool {
+	return f.Summary != nil || f.Description != nil || f.StartsAt != nil || f.EndsAt != nil || f.AllDay != nil ||
+		f.Participants != nil || f.ClearParticipants || f.NoRepeat || f.hasRecurrence()
+}
+
+// eventTimes resolves --starts-at and --ends-at against the current
+// values. An --ends-at offset like "+1h" is relative to the start, and
+// moving only the start keeps the event's duration.
+func eventTimes(startsAt, endsAt *string, currentStart, currentEnd string, now time.Time) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+
+	if currentStart != "" {
+		if start, err = time.Parse(time.RFC3339, currentStart); err != nil {
+			return start, end, fmt.Errorf("invalid starts_at '%s'", currentStart)
+		}
+	}
+	if currentEnd != "" {
+		if end, err = time.Parse(time.RFC3339, currentEnd); err != nil {
+			return start, end, fmt.Errorf("invalid ends_at '%s'", currentEnd)
+		}
+	}
+
+	if startsAt != nil {
+		newStart, err := parseNaturalTime(*startsAt, now)
+		if err != nil {
+			return start, end, fmt.Errorf("--starts-at: %w", err)
+		}
+		if endsAt == nil && !start.IsZero() && !end.IsZero() {
+			end = newStart.Add(end.Sub(start))
+		}
+		start = newStart
+	}
+
+	if endsAt != nil {
+		base := now
+		if strings.HasPrefix(*endsAt, "+") {
+			base = start
+		}
+		if end, err = parseNaturalTime(*endsAt, base); err != nil {
+			return start, end, fmt.Errorf("--ends-at: %w", err)
+		}
+	}
+
+	if end.Before(start) {
+		return start, end, errors.New("--ends-at must not be before --starts-at")
+	}
+	return start, end, nil
+}
+
+// buildRecurrence turns the --repeat flags into a recurrence_schedule for
+// an event starting at start
+func (f eventFlags) buildRecurrence(start, now time.Time) (*RecurrenceSchedule, error) {
+	frequency, ok := recurrenceFrequencies[strings.ToLower(f.Repeat)]
+	if !ok {
+		return nil, errors.New("--repeat must be daily, weekly, monthly or yearly")
+	}
+
+	return f.applyRecurrence(&RecurrenceSchedule{Frequency: frequency}, start, now)
+}
+
+// updateRecurrence applies the --repeat flags to an event's current
+// recurrence_schedule, keeping whatever they do not change. A new or
+// different --repeat frequency starts the schedule afresh.
+func (f eventFlags) updateRecurrence(current *RecurrenceSchedule, start, now time.Time) (*RecurrenceSchedule, error) {
+	if current == nil || (f.Repeat != "" && recurrenceFrequencies[strings.ToLower(f.Repeat)] != current.Frequency) {
+		return f.buildRecurrence(start, now)
+	}
+
+	r := *current
+	r.Days = append([]int(nil), current.Days...)
+	return f.applyRecurrence(&r, start, now)
+}
+
+// applyRecurrence sets the fields of r given by the --repeat flags
+func (f eventFlags) applyRecurrence(r *RecurrenceSchedule, start, now time.Time) (*RecurrenceSchedule, error) {
+	frequency := r.Frequency
+	r.StartDate = start.Format("2006-01-02")
+
+	if f.RepeatDays != "" {
+		r.Days = nil
+		for _, name := range splitComma(f.RepeatDays) {
+			weekday, ok := weekdayNames[strings.ToLower(name)]
+			if !ok {
+				weekday, ok = rruleWeekdays[strings.ToUpper(name)]
+			}
+			if !ok {
+				return nil, fmt.Errorf("invalid day '%s' in --repeat-days", name)
+			}
+			r.Days = append(r.Days, int(weekday))
+		}
+	}
+
+	if f.RepeatEvery != "" {
+		n, err := strconv.Atoi(f.RepeatEvery)
+		if err != nil || n < 1 {
+			return nil, errors.New("--repeat-every must be a positive number")
+		}
+		switch frequency {
+		case "every_week":
+			r.WeekInterval = &n
+		case "every_month":
+			r.MonthInterval = &n
+		default:
+			return nil, errors.New("--repeat-every only applies to weekly and monthly events")
+		}
+	}
+
+	if f.RepeatWeek != "" {
+		if frequency != "every_month" {
+			return nil, errors.New("--repeat-week only applies to monthly events")
+		}
+		n, err := strconv.Atoi(f.RepeatWeek)
+		if f.RepeatWeek == "last" {
+			n, err = -1, nil
 		}
+		if err != nil || n == 0 || n < -1 || n > 4 {
+			return nil, errors.New("--repeat-week must be 1-4 or last")
+		}
+		r.WeekInstance = &n
+	}
+
+	// Weekly events, and monthly ones on the nth weekday, need the days
+	if len(r.Days) == 0 && (frequency == "every_week" || r.WeekInstance != nil) {
+		r.Days = []int{int(start.Weekday())}
 	}
 
-	if summary == "" {
+	if f.RepeatUntil != "" {
+		until, err := parseNaturalTime(f.RepeatUntil, now)
+		if err != nil {
+			return nil, fmt.Errorf("--repeat-until: %w", err)
+		}
+		r.EndDate = until.Format("2006-01-02")
+	}
+
+	return r, nil
+}
+
+// eventPayload builds the body for creating a schedule entry
+func eventPayload(summary, description string, start, end time.Time, allDay bool) map[string]any {
+	payload := map[string]any{
+		"summary":   summary,
+		"starts_at": start.Format(time.RFC3339),
+		"ends_at":   end.Format(time.RFC3339),
+		"all_day":   allDay,
+	}
+	if description != "" {
+		payload["description"] = description
+	}
+	return payload
+}
+
+func (c *EventCreateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	f := parseEventFlags(remaining)
+
+	if stringValue(f.Summary) == "" {
 		return errors.New("--summary required")
 	}
-	if startsAt == "" {
+	if stringValue(f.StartsAt) == "" {
 		return errors.New("--starts-at required")
 	}
-	if endsAt == "" {
+	if stringValue(f.EndsAt) == "" {
 		return errors.New("--ends-at required")
 	}
 
-	cl, err := client.New()
+	now := time.Now()
+	start, end, err := eventTimes(f.StartsAt, f.EndsAt, "", "", now)
 	if err != nil {
 		return err
 	}
 
-	_, schedule, err := fetchSchedule(cl, projectID)
+	payload := eventPayload(*f.Summary, stringValue(f.Description), start, end, f.AllDay != nil && *f.AllDay)
+	if f.hasRecurrence() {
+		recurrence, err := f.buildRecurrence(start, now)
+		if err != nil {
+			return err
+		}
+		payload["recurrence_schedule"] = recurrence
+	}
+
+	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
-	// Create entry
-	payload := map[string]any{
-		"summary":   summary,
-		"starts_at": startsAt,
-		"ends_at":   endsAt,
-		"all_day":   allDay,
+	if stringValue(f.Participants) != "" {
+		participantIDs, err := resolvePeopleList(cl, *f.Participants)
+		if err != nil {
+			return err
+		}
+		payload["participant_ids"] = participantIDs
 	}
-	if description != "" {
-		payload["description"] = description
+	if f.Notify != nil {
+		payload["notify"] = *f.Notify
+	}
+
+	_, schedule, err := fetchSchedule(cl, projectID)
+	if err != nil {
+		return err
 	}
 
 	// POST to entries URL
@@ -306,3 +992,145 @@ func (c *EventCreateCmd) Run(args []string) error {
 		Message: fmt.Sprintf("Event '%s' created", created.Summary),
 	})
 }
+
+// EventUpdateCmd changes an existing schedule entry
+type EventUpdateCmd struct{}
+
+func (c *EventUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	f := parseEventFlags(remaining)
+	if len(f.Args) < 1 {
+		return errors.New("usage: basecamp event-update [project_id] <entry_id> [--summary <text>] [--description <text>] [--starts-at <time>] [--ends-at <time>] [--all-day|--no-all-day] [--participants <people>] [--clear-participants] [--repeat <freq> ...] [--no-repeat] [--notify]")
+	}
+	entryID := f.Args[0]
+
+	if !f.hasChanges() {
+		return errors.New("at least one of --summary, --description, --starts-at, --ends-at, --all-day, --participants, --repeat or a --clear-participants/--no-repeat flag required")
+	}
+	if f.NoRepeat && f.hasRecurrence() {
+		return errors.New("--no-repeat cannot be combined with --repeat options")
+	}
+	if f.Participants != nil && f.ClearParticipants {
+		return errors.New("--participants cannot be combined with --clear-participants")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Fetch the entry so fields that are not given keep their values
+	path := "/buckets/" + projectID + "/schedule_entries/" + entryID + ".json"
+	data, err := cl.Get(path)
+	if err != nil {
+		return err
+	}
+
+	var current ScheduleEntry
+	if err := json.Unmarshal(data, &current); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	start, end, err := eventTimes(f.StartsAt, f.EndsAt, current.StartsAt, current.EndsAt, now)
+	if err != nil {
+		return err
+	}
+
+	participantIDs := make([]int, len(current.Participants))
+	for i, p := range current.Participants {
+		participantIDs[i] = p.ID
+	}
+
+	payload := map[string]any{
+		"summary":         coalesce(stringValue(f.Summary), current.Summary),
+		"description":     current.Description,
+		"starts_at":       start.Format(time.RFC3339),
+		"ends_at":         end.Format(time.RFC3339),
+		"all_day":         current.AllDay,
+		"participant_ids": participantIDs,
+	}
+	if f.Description != nil {
+		payload["description"] = *f.Description
+	}
+	if f.AllDay != nil {
+		payload["all_day"] = *f.AllDay
+	}
+
+	if f.ClearParticipants {
+		payload["participant_ids"] = []int{}
+	} else if f.Participants != nil {
+		ids, err := resolvePeopleList(cl, *f.Participants)
+		if err != nil {
+			return err
+		}
+		payload["participant_ids"] = ids
+	}
+
+	switch {
+	case f.NoRepeat:
+		payload["recurrence_schedule"] = nil
+	case f.hasRecurrence():
+		recurrence, err := f.updateRecurrence(current.RecurrenceSchedule, start, now)
+		if err != nil {
+			return err
+		}
+		payload["recurrence_schedule"] = recurrence
+	}
+
+	if f.Notify != nil {
+		payload["notify"] = *f.Notify
+	}
+
+	data, err = cl.Put(path, payload)
+	if err != nil {
+		return err
+	}
+
+	var updated ScheduleEntry
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return err
+	}
+
+	return PrintJSON(EventCreateOutput{
+		Status:  "ok",
+		ID:      updated.ID,
+		Summary: updated.Summary,
+		Message: fmt.Sprintf("Event '%s' updated", updated.Summary),
+	})
+}
+
+// EventTrashCmd moves a schedule entry to the trash
+type EventTrashCmd struct{}
+
+func (c *EventTrashCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp event-trash [project_id] <entry_id>")
+	}
+	entryID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.Put("/buckets/"+projectID+"/recordings/"+entryID+"/status/trashed.json", nil)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":   "ok",
+		"entry_id": entryID,
+		"message":  "Event moved to trash",
+	})
+}
@@ -0,0 +1,434 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventTimes(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name                     string
+		startsAt, endsAt         *string
+		currentStart, currentEnd string
+		wantStart, wantEnd       string
+		wantErr                  bool
+	}{
+		{
+			name:      "new event with end offset from start",
+			startsAt:  str("tomorrow 14:00"),
+			endsAt:    str("+90m"),
+			wantStart: "2026-03-05T14:00:00Z",
+			wantEnd:   "2026-03-05T15:30:00Z",
+		},
+		{
+			name:         "moving the start keeps the duration",
+			startsAt:     str("2026-03-10 09:00"),
+			currentStart: "2026-03-05T14:00:00Z",
+			currentEnd:   "2026-03-05T16:00:00Z",
+			wantStart:    "2026-03-10T09:00:00Z",
+			wantEnd:      "2026-03-10T11:00:00Z",
+		},
+		{
+			name:         "only the end",
+			endsAt:       str("2026-03-05 17:00"),
+			currentStart: "2026-03-05T14:00:00Z",
+			currentEnd:   "2026-03-05T16:00:00Z",
+			wantStart:    "2026-03-05T14:00:00Z",
+			wantEnd:      "2026-03-05T17:00:00Z",
+		},
+		{
+			name:     "end before start",
+			startsAt: str("2026-03-05 14:00"),
+			endsAt:   str("2026-03-05 13:00"),
+			wantErr:  true,
+		},
+		{
+			name:     "unparseable start",
+			startsAt: str("whenever"),
+			endsAt:   str("+1h"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := eventTimes(tt.startsAt, tt.endsAt, tt.currentStart, tt.currentEnd, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if start.Format(time.RFC3339) != tt.wantStart || end.Format(time.RFC3339) != tt.wantEnd {
+				t.Errorf("got %s - %s, want %s - %s", start.Format(time.RFC3339), end.Format(time.RFC3339), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestBuildRecurrence(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	// A Thursday
+	start := time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC)
+	two, last := 2, -1
+
+	tests := []struct {
+		name    string
+		flags   eventFlags
+		want    *RecurrenceSchedule
+		wantErr bool
+	}{
+		{
+			name:  "weekly defaults to the start day",
+			flags: eventFlags{Repeat: "weekly"},
+			want:  &RecurrenceSchedule{Frequency: "every_week", Days: []int{4}, StartDate: "2026-03-05"},
+		},
+		{
+			name:  "every other week on given days until a date",
+			flags: eventFlags{Repeat: "Weekly", RepeatDays: "mon, WE", RepeatEvery: "2", RepeatUntil: "2026-06-30"},
+			want:  &RecurrenceSchedule{Frequency: "every_week", Days: []int{1, 3}, WeekInterval: &two, StartDate: "2026-03-05", EndDate: "2026-06-30"},
+		},
+		{
+			name:  "last thursday of the month",
+			flags: eventFlags{Repeat: "monthly", RepeatWeek: "last"},
+			want:  &RecurrenceSchedule{Frequency: "every_month", Days: []int{4}, WeekInstance: &last, StartDate: "2026-03-05"},
+		},
+		{
+			name:  "daily",
+			flags: eventFlags{Repeat: "daily"},
+			want:  &RecurrenceSchedule{Frequency: "every_day", StartDate: "2026-03-05"},
+		},
+		{name: "unknown frequency", flags: eventFlags{Repeat: "hourly"}, wantErr: true},
+		{name: "missing frequency", flags: eventFlags{RepeatDays: "mo"}, wantErr: true},
+		{name: "bad day", flags: eventFlags{Repeat: "weekly", RepeatDays: "funday"}, wantErr: true},
+		{name: "interval on daily", flags: eventFlags{Repeat: "daily", RepeatEvery: "2"}, wantErr: true},
+		{name: "week instance on weekly", flags: eventFlags{Repeat: "weekly", RepeatWeek: "1"}, wantErr: true},
+		{name: "week instance out of range", flags: eventFlags{Repeat: "monthly", RepeatWeek: "5"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.buildRecurrence(start, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildRecurrence() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRecurrence(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	start := time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC)
+	two, three, first := 2, 3, 1
+
+	// Every other week on Tuesday and Thursday
+	current := &RecurrenceSchedule{Frequency: "every_week", Days: []int{2, 4}, WeekInterval: &two, StartDate: "2026-03-05"}
+	monthly := &RecurrenceSchedule{Frequency: "every_month", Days: []int{4}, WeekInstance: &first, StartDate: "2026-03-05", EndDate: "2026-12-31"}
+
+	tests := []struct {
+		name    string
+		flags   eventFlags
+		current *RecurrenceSchedule
+		want    *RecurrenceSchedule
+		wantErr bool
+	}{
+		{
+			name:    "end date keeps days and interval",
+			flags:   eventFlags{RepeatUntil: "2026-06-30"},
+			current: current,
+			want:    &RecurrenceSchedule{Frequency: "every_week", Days: []int{2, 4}, WeekInterval: &two, StartDate: "2026-03-05", EndDate: "2026-06-30"},
+		},
+		{
+			name:    "days keep interval",
+			flags:   eventFlags{RepeatDays: "mo"},
+			current: current,
+			want:    &RecurrenceSchedule{Frequency: "every_week", Days: []int{1}, WeekInterval: &two, StartDate: "2026-03-05"},
+		},
+		{
+			name:    "same frequency keeps the rest",
+			flags:   eventFlags{Repeat: "monthly", RepeatEvery: "3"},
+			current: monthly,
+			want:    &RecurrenceSchedule{Frequency: "every_month", Days: []int{4}, WeekInstance: &first, MonthInterval: &three, StartDate: "2026-03-05", EndDate: "2026-12-31"},
+		},
+		{
+			name:    "new frequency starts afresh",
+			flags:   eventFlags{Repeat: "daily"},
+			current: current,
+			want:    &RecurrenceSchedule{Frequency: "every_day", StartDate: "2026-03-05"},
+		},
+		{
+			name:  "no current repeat",
+			flags: eventFlags{Repeat: "weekly"},
+			want:  &RecurrenceSchedule{Frequency: "every_week", Days: []int{4}, StartDate: "2026-03-05"},
+		},
+		{name: "no current repeat or frequency", flags: eventFlags{RepeatUntil: "2026-06-30"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.flags.updateRecurrence(tt.current, start, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updateRecurrence() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if len(current.Days) != 2 || current.Days[0] != 2 || current.EndDate != "" {
+		t.Errorf("current schedule was modified: %+v", current)
+	}
+}
+
+func TestParseEventFlags(t *testing.T) {
+	f := parseEventFlags([]string{"123", "--summary", "Standup", "--no-notify", "--repeat", "weekly", "--clear-participants", "--ends-at"})
+
+	if !reflect.DeepEqual(f.Args, []string{"123"}) {
+		t.Errorf("Args = %v", f.Args)
+	}
+	if stringValue(f.Summary) != "Standup" || f.Notify == nil || *f.Notify {
+		t.Errorf("unexpected flags: %+v", f)
+	}
+	if !f.hasRecurrence() || !f.ClearParticipants {
+		t.Errorf("unexpected flags: %+v", f)
+	}
+	if f.EndsAt != nil || f.Description != nil {
+		t.Error("expected flags without values to stay nil")
+	}
+}
+
+func TestEventFlagsHasChanges(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"123"}, false},
+		{[]string{"123", "--notify"}, false},
+		{[]string{"123", "--summary", "Standup"}, true},
+		{[]string{"123", "--no-all-day"}, true},
+		{[]string{"123", "--repeat-until", "2026-12-31"}, true},
+		{[]string{"123", "--no-repeat"}, true},
+	}
+	for _, tt := range tests {
+		if got := parseEventFlags(tt.args).hasChanges(); got != tt.want {
+			t.Errorf("hasChanges(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRecurrenceRRule(t *testing.T) {
+	two, last := 2, -1
+
+	tests := []struct {
+		name string
+		in   RecurrenceSchedule
+		want rrule
+	}{
+		{
+			name: "daily",
+			in:   RecurrenceSchedule{Frequency: "every_day"},
+			want: rrule{Freq: "DAILY", Interval: 1},
+		},
+		{
+			name: "every other week",
+			in:   RecurrenceSchedule{Frequency: "every_week", Days: []int{1, 3}, WeekInterval: &two, EndDate: "2026-06-30"},
+			want: rrule{Freq: "WEEKLY", Interval: 2, ByDay: []rruleDay{{Weekday: time.Monday}, {Weekday: time.Wednesday}}, Until: day("2026-06-30")},
+		},
+		{
+			name: "last friday monthly",
+			in:   RecurrenceSchedule{Frequency: "every_month", Days: []int{5}, WeekInstance: &last},
+			want: rrule{Freq: "MONTHLY", Interval: 1, ByDay: []rruleDay{{Ordinal: -1, Weekday: time.Friday}}},
+		},
+		{
+			name: "monthly by date ignores days",
+			in:   RecurrenceSchedule{Frequency: "every_month", Days: []int{5}, MonthInterval: &two},
+			want: rrule{Freq: "MONTHLY", Interval: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := recurrenceRRule(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recurrenceRRule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := recurrenceRRule(RecurrenceSchedule{Frequency: "every_hour"}); err == nil {
+		t.Error("expected error for unknown frequency")
+	}
+}
+
+func TestFilterScheduleEntries(t *testing.T) {
+	entries := []ScheduleEntry{
+		{
+			ID:           1,
+			Summary:      "Standup",
+			StartsAt:     "2026-03-02T09:30:00-05:00",
+			EndsAt:       "2026-03-02T09:45:00-05:00",
+			Participants: []Creator{{ID: 7, Name: "Jane"}},
+			RecurrenceSchedule: &RecurrenceSchedule{
+				Frequency: "every_week",
+				Days:      []int{1, 3},
+			},
+		},
+		{ID: 2, Summary: "Launch", StartsAt: "2026-03-12T14:00:00Z", EndsAt: "2026-03-12T15:00:00Z"},
+		{ID: 3, Summary: "Offsite", StartsAt: "2026-03-05T00:00:00Z", EndsAt: "2026-03-06T23:59:59Z", AllDay: true},
+		{ID: 4, Summary: "Old", StartsAt: "2026-01-01T10:00:00Z", EndsAt: "2026-01-01T11:00:00Z"},
+	}
+
+	summaries := func(briefs []ScheduleEntryBrief) []string {
+		out := make([]string, len(briefs))
+		for i, b := range briefs {
+			out[i] = b.Summary + "@" + b.StartsAt
+		}
+		return out
+	}
+
+	t.Run("window expands recurring entries", func(t *testing.T) {
+		f := scheduleFilter{
+			From: time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		}
+		got, err := filterScheduleEntries(entries, f)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"Offsite@2026-03-05T00:00:00Z",
+			"Standup@2026-03-09T09:30:00-05:00",
+			"Standup@2026-03-11T09:30:00-05:00",
+			"Launch@2026-03-12T14:00:00Z",
+		}
+		if !reflect.DeepEqual(summaries(got), want) {
+			t.Errorf("got %v, want %v", summaries(got), want)
+		}
+		if !got[1].Recurring || got[1].EndsAt != "2026-03-09T09:45:00-05:00" {
+			t.Errorf("unexpected occurrence: %+v", got[1])
+		}
+	})
+
+	t.Run("participant and upcoming", func(t *testing.T) {
+		f := scheduleFilter{
+			From:          time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:            time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+			ParticipantID: 7,
+			Upcoming:      3,
+		}
+		got, err := filterScheduleEntries(entries, f)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"Standup@2026-03-02T09:30:00-05:00",
+			"Standup@2026-03-04T09:30:00-05:00",
+			"Standup@2026-03-09T09:30:00-05:00",
+		}
+		if !reflect.DeepEqual(summaries(got), want) {
+			t.Errorf("got %v, want %v", summaries(got), want)
+		}
+	})
+
+	t.Run("occurrences keep their time across daylight saving", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skipf("time zone data unavailable: %v", err)
+		}
+		f := scheduleFilter{
+			From:     time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+			Location: newYork,
+		}
+		got, err := filterScheduleEntries(entries[:1], f)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Clocks go forward on March 8, so the offset changes but 09:30 stays
+		want := []string{"Standup@2026-03-09T09:30:00-04:00"}
+		if !reflect.DeepEqual(summaries(got), want) {
+			t.Errorf("got %v, want %v", summaries(got), want)
+		}
+	})
+
+	t.Run("open window keeps entries as they are", func(t *testing.T) {
+		got, err := filterScheduleEntries(entries, scheduleFilter{From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 3 || got[0].ID != 1 || got[0].Recurring {
+			t.Errorf("unexpected entries: %v", summaries(got))
+		}
+	})
+}
+
+func TestRRuleRecurrence(t *testing.T) {
+	two, last := 2, -1
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		rule string
+		want RecurrenceSchedule
+	}{
+		{"FREQ=DAILY", RecurrenceSchedule{Frequency: "every_day"}},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", RecurrenceSchedule{Frequency: "every_week", Days: []int{1, 2, 3, 4, 5}}},
+		{"FREQ=WEEKLY;INTERVAL=2;UNTIL=20260630T000000Z", RecurrenceSchedule{Frequency: "every_week", Days: []int{1}, WeekInterval: &two, EndDate: "2026-06-30"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR", RecurrenceSchedule{Frequency: "every_month", Days: []int{5}, WeekInstance: &last}},
+		{"FREQ=MONTHLY;BYMONTHDAY=5;INTERVAL=2", RecurrenceSchedule{Frequency: "every_month", MonthInterval: &two}},
+		{"FREQ=WEEKLY;BYDAY=MO;COUNT=3", RecurrenceSchedule{Frequency: "every_week", Days: []int{1}, EndDate: "2026-01-19"}},
+		{"FREQ=YEARLY;WKST=SU", RecurrenceSchedule{Frequency: "every_year"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rr, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule() error: %v", err)
+			}
+			got, err := rruleRecurrence(rr, start)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.want.StartDate = "2026-01-05"
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("rruleRecurrence() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	for _, rule := range []string{"FREQ=DAILY;INTERVAL=3", "FREQ=MONTHLY;BYMONTHDAY=1,15", "FREQ=MONTHLY;BYDAY=1MO,2TU", "FREQ=YEARLY;INTERVAL=2"} {
+		rr, err := parseRRule(rule)
+		if err != nil {
+			t.Fatalf("parseRRule(%q) error: %v", rule, err)
+		}
+		if _, err := rruleRecurrence(rr, start); err == nil {
+			t.Errorf("rruleRecurrence(%q) expected an error", rule)
+		}
+	}
+}
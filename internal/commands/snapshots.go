@@ -0,0 +1,423 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+// BoardSnapshot is the state of a card table at a point in time
+type BoardSnapshot struct {
+	ProjectID  int              `json:"project_id"`
+	BoardID    int              `json:"board_id"`
+	BoardTitle string           `json:"board_title"`
+	TakenAt    string           `json:"taken_at"`
+	Columns    []SnapshotColumn `json:"columns"`
+}
+
+type SnapshotColumn struct {
+	ID    int            `json:"id"`
+	Title string         `json:"title"`
+	Cards []SnapshotCard `json:"cards"`
+}
+
+type SnapshotCard struct {
+	ID        int            `json:"id"`
+	Title     string         `json:"title"`
+	DueOn     string         `json:"due_on,omitempty"`
+	Completed bool           `json:"completed"`
+	Assignees []Assignee     `json:"assignees"`
+	Steps     []SnapshotStep `json:"steps"`
+}
+
+type SnapshotStep struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// fetchBoardSnapshot captures every column and card of a card table
+func fetchBoardSnapshot(cl *client.Client, projectID, boardID string) (BoardSnapshot, error) {
+	data, err := cl.Get("/buckets/" + projectID + "/card_tables/" + boardID + ".json")
+	if err != nil {
+		return BoardSnapshot{}, err
+	}
+
+	var cardTable CardTableDetail
+	if err := json.Unmarshal(data, &cardTable); err != nil {
+		return BoardSnapshot{}, err
+	}
+
+	snapshot := BoardSnapshot{
+		BoardID:    cardTable.ID,
+		BoardTitle: cardTable.Title,
+		TakenAt:    time.Now().UTC().Format(time.RFC3339),
+		Columns:    make([]SnapshotColumn, len(cardTable.Lists)),
+	}
+	snapshot.ProjectID, _ = strconv.Atoi(projectID)
+
+	for i, list := range cardTable.Lists {
+		column := SnapshotColumn{
+			ID:    list.ID,
+			Title: list.Title,
+			Cards: []SnapshotCard{},
+		}
+
+		if list.CardsCount > 0 {
+			cardsData, err := cl.GetAll(list.CardsURL)
+			if err != nil {
+				return BoardSnapshot{}, err
+			}
+
+			for _, cardJSON := range cardsData {
+				var card CardSummary
+				if err := json.Unmarshal(cardJSON, &card); err != nil {
+					return BoardSnapshot{}, err
+				}
+				column.Cards = append(column.Cards, cardToSnapshot(card))
+			}
+		}
+
+		snapshot.Columns[i] = column
+	}
+
+	return snapshot, nil
+}
+
+func cardToSnapshot(card CardSummary) SnapshotCard {
+	sc := SnapshotCard{
+		ID:        card.ID,
+		Title:     card.Title,
+		DueOn:     card.DueOn,
+		Completed: card.Completed,
+		Assignees: []Assignee{},
+		Steps:     make([]SnapshotStep, len(card.Steps)),
+	}
+	sc.Assignees = append(sc.Assignees, card.Assignees...)
+	for i, s := range card.Steps {
+		sc.Steps[i] = SnapshotStep{ID: s.ID, Title: s.Title, Completed: s.Completed}
+	}
+	return sc
+}
+
+func loadBoardSnapshot(path string) (BoardSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return BoardSnapshot{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot BoardSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return BoardSnapshot{}, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// BoardSnapshotCmd captures a card table to a JSON file
+type BoardSnapshotCmd struct{}
+
+type BoardSnapshotOutput struct {
+	Status  string `json:"status"`
+	BoardID int    `json:"board_id"`
+	File    string `json:"file"`
+	Columns int    `json:"columns"`
+	Cards   int    `json:"cards"`
+	Message string `json:"message"`
+}
+
+func (c *BoardSnapshotCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	// Parse board_id and flags
+	var boardID, out string
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--out":
+			if i+1 < len(remaining) {
+				out = remaining[i+1]
+				i++
+			}
+		default:
+			if boardID == "" {
+				boardID = remaining[i]
+			}
+		}
+	}
+
+	if boardID == "" {
+		return errors.New("usage: basecamp board-snapshot [project_id] <board_id> [--out <file>]")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := fetchBoardSnapshot(cl, projectID, boardID)
+	if err != nil {
+		return err
+	}
+
+	// Without --out the snapshot itself is the output
+	if out == "" {
+		return PrintJSON(snapshot)
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	cards := 0
+	for _, col := range snapshot.Columns {
+		cards += len(col.Cards)
+	}
+
+	return PrintJSON(BoardSnapshotOutput{
+		Status:  "ok",
+		BoardID: snapshot.BoardID,
+		File:    out,
+		Columns: len(snapshot.Columns),
+		Cards:   cards,
+		Message: fmt.Sprintf("Snapshot of '%s' written to %s", snapshot.BoardTitle, out),
+	})
+}
+
+// BoardDiff describes how a card table changed between two snapshots
+type BoardDiff struct {
+	BoardID    int              `json:"board_id"`
+	BoardTitle string           `json:"board_title"`
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Added      []CardDiffEntry  `json:"added"`
+	Removed    []CardDiffEntry  `json:"removed"`
+	Moved      []CardMove       `json:"moved"`
+	Retitled   []CardRetitle    `json:"retitled"`
+	Reassigned []CardReassigned `json:"reassigned"`
+}
+
+type CardDiffEntry struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Column string `json:"column"`
+}
+
+type CardMove struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type CardRetitle struct {
+	ID   int    `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type CardReassigned struct {
+	ID      int      `json:"id"`
+	Title   string   `json:"title"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type snapshotCardRef struct {
+	card   SnapshotCard
+	column string
+}
+
+func indexSnapshot(s BoardSnapshot) (map[int]snapshotCardRef, []int) {
+	index := make(map[int]snapshotCardRef)
+	var order []int
+	for _, col := range s.Columns {
+		for _, card := range col.Cards {
+			index[card.ID] = snapshotCardRef{card: card, column: col.Title}
+			order = append(order, card.ID)
+		}
+	}
+	return index, order
+}
+
+// diffSnapshots compares two snapshots of the same board. Cards are
+// matched by ID, so a retitled card is reported as retitled rather than
+// as one removal and one addition.
+func diffSnapshots(before, after BoardSnapshot) BoardDiff {
+	diff := BoardDiff{
+		BoardID:    after.BoardID,
+		BoardTitle: after.BoardTitle,
+		From:       before.TakenAt,
+		To:         after.TakenAt,
+		Added:      []CardDiffEntry{},
+		Removed:    []CardDiffEntry{},
+		Moved:      []CardMove{},
+		Retitled:   []CardRetitle{},
+		Reassigned: []CardReassigned{},
+	}
+
+	oldIndex, oldOrder := indexSnapshot(before)
+	newIndex, newOrder := indexSnapshot(after)
+
+	for _, id := range newOrder {
+		cur := newIndex[id]
+		prev, ok := oldIndex[id]
+		if !ok {
+			diff.Added = append(diff.Added, CardDiffEntry{ID: id, Title: cur.card.Title, Column: cur.column})
+			continue
+		}
+
+		if prev.column != cur.column {
+			diff.Moved = append(diff.Moved, CardMove{ID: id, Title: cur.card.Title, From: prev.column, To: cur.column})
+		}
+		if prev.card.Title != cur.card.Title {
+			diff.Retitled = append(diff.Retitled, CardRetitle{ID: id, From: prev.card.Title, To: cur.card.Title})
+		}
+
+		added, removed := diffAssignees(prev.card.Assignees, cur.card.Assignees)
+		if len(added) > 0 || len(removed) > 0 {
+			diff.Reassigned = append(diff.Reassigned, CardReassigned{ID: id, Title: cur.card.Title, Added: added, Removed: removed})
+		}
+	}
+
+	for _, id := range oldOrder {
+		if _, ok := newIndex[id]; !ok {
+			prev := oldIndex[id]
+			diff.Removed = append(diff.Removed, CardDiffEntry{ID: id, Title: prev.card.Title, Column: prev.column})
+		}
+	}
+
+	return diff
+}
+
+// diffAssignees returns the names of people added and removed, by ID
+func diffAssignees(before, after []Assignee) (added, removed []string) {
+	oldIDs := make(map[int]bool)
+	for _, a := range before {
+		oldIDs[a.ID] = true
+	}
+	newIDs := make(map[int]bool)
+	for _, a := range after {
+		newIDs[a.ID] = true
+		if !oldIDs[a.ID] {
+			added = append(added, a.Name)
+		}
+	}
+	for _, a := range before {
+		if !newIDs[a.ID] {
+			removed = append(removed, a.Name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// formatBoardDiff renders a diff as human-readable lines
+func formatBoardDiff(d BoardDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Board '%s' changes from %s to %s\n", d.BoardTitle, d.From, d.To)
+
+	total := len(d.Added) + len(d.Removed) + len(d.Moved) + len(d.Retitled) + len(d.Reassigned)
+	if total == 0 {
+		b.WriteString("No changes\n")
+		return b.String()
+	}
+
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "+ #%d %q added to %s\n", c.ID, c.Title, c.Column)
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&b, "- #%d %q removed from %s\n", c.ID, c.Title, c.Column)
+	}
+	for _, c := range d.Moved {
+		fmt.Fprintf(&b, "> #%d %q moved %s -> %s\n", c.ID, c.Title, c.From, c.To)
+	}
+	for _, c := range d.Retitled {
+		fmt.Fprintf(&b, "~ #%d retitled %q -> %q\n", c.ID, c.From, c.To)
+	}
+	for _, c := range d.Reassigned {
+		var parts []string
+		if len(c.Added) > 0 {
+			parts = append(parts, "+"+strings.Join(c.Added, ", +"))
+		}
+		if len(c.Removed) > 0 {
+			parts = append(parts, "-"+strings.Join(c.Removed, ", -"))
+		}
+		fmt.Fprintf(&b, "@ #%d %q reassigned %s\n", c.ID, c.Title, strings.Join(parts, " "))
+	}
+	return b.String()
+}
+
+// BoardDiffCmd compares two board snapshots, or a snapshot with the live board
+type BoardDiffCmd struct{}
+
+func (c *BoardDiffCmd) Run(args []string) error {
+	// Snapshots carry their own project and board IDs, so no project_id here
+	var files []string
+	summary := false
+
+	for _, arg := range args {
+		if arg == "--summary" {
+			summary = true
+		} else {
+			files = append(files, arg)
+		}
+	}
+
+	if len(files) != 2 {
+		return errors.New("usage: basecamp board-diff <old.json> <new.json|live> [--summary]")
+	}
+
+	old, err := loadBoardSnapshot(files[0])
+	if err != nil {
+		return err
+	}
+
+	var current BoardSnapshot
+	if files[1] == "live" {
+		if old.ProjectID == 0 || old.BoardID == 0 {
+			return fmt.Errorf("%s has no project_id/board_id to compare against live", files[0])
+		}
+
+		cl, err := client.New()
+		if err != nil {
+			return err
+		}
+
+		current, err = fetchBoardSnapshot(cl, strconv.Itoa(old.ProjectID), strconv.Itoa(old.BoardID))
+		if err != nil {
+			return err
+		}
+	} else {
+		current, err = loadBoardSnapshot(files[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	if old.BoardID != current.BoardID {
+		return fmt.Errorf("snapshots are of different boards (%d and %d)", old.BoardID, current.BoardID)
+	}
+
+	diff := diffSnapshots(old, current)
+
+	if summary {
+		fmt.Print(formatBoardDiff(diff))
+		return nil
+	}
+	return PrintJSON(diff)
+}
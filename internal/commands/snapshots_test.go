@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testSnapshot(takenAt string, columns ...SnapshotColumn) BoardSnapshot {
+	return BoardSnapshot{ProjectID: 1, BoardID: 2, BoardTitle: "Sprint", TakenAt: takenAt, Columns: columns}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	jane := Assignee{ID: 10, Name: "Jane"}
+	bob := Assignee{ID: 11, Name: "Bob"}
+
+	before := testSnapshot("2026-01-01T00:00:00Z",
+		SnapshotColumn{Title: "Todo", Cards: []SnapshotCard{
+			{ID: 1, Title: "Login page", Assignees: []Assignee{jane}},
+			{ID: 2, Title: "Signup"},
+			{ID: 3, Title: "Old card"},
+		}},
+		SnapshotColumn{Title: "Doing", Cards: []SnapshotCard{
+			{ID: 4, Title: "API", Assignees: []Assignee{jane}},
+		}},
+	)
+	after := testSnapshot("2026-01-15T00:00:00Z",
+		SnapshotColumn{Title: "Todo", Cards: []SnapshotCard{
+			{ID: 2, Title: "Signup flow"},
+			{ID: 5, Title: "New card"},
+		}},
+		SnapshotColumn{Title: "Doing", Cards: []SnapshotCard{
+			{ID: 1, Title: "Login page", Assignees: []Assignee{jane}},
+			{ID: 4, Title: "API", Assignees: []Assignee{bob}},
+		}},
+	)
+
+	diff := diffSnapshots(before, after)
+
+	if diff.From != before.TakenAt || diff.To != after.TakenAt {
+		t.Errorf("From/To = %s/%s, want snapshot times", diff.From, diff.To)
+	}
+
+	wantAdded := []CardDiffEntry{{ID: 5, Title: "New card", Column: "Todo"}}
+	if !reflect.DeepEqual(diff.Added, wantAdded) {
+		t.Errorf("Added = %+v, want %+v", diff.Added, wantAdded)
+	}
+
+	wantRemoved := []CardDiffEntry{{ID: 3, Title: "Old card", Column: "Todo"}}
+	if !reflect.DeepEqual(diff.Removed, wantRemoved) {
+		t.Errorf("Removed = %+v, want %+v", diff.Removed, wantRemoved)
+	}
+
+	wantMoved := []CardMove{{ID: 1, Title: "Login page", From: "Todo", To: "Doing"}}
+	if !reflect.DeepEqual(diff.Moved, wantMoved) {
+		t.Errorf("Moved = %+v, want %+v", diff.Moved, wantMoved)
+	}
+
+	wantRetitled := []CardRetitle{{ID: 2, From: "Signup", To: "Signup flow"}}
+	if !reflect.DeepEqual(diff.Retitled, wantRetitled) {
+		t.Errorf("Retitled = %+v, want %+v", diff.Retitled, wantRetitled)
+	}
+
+	wantReassigned := []CardReassigned{{ID: 4, Title: "API", Added: []string{"Bob"}, Removed: []string{"Jane"}}}
+	if !reflect.DeepEqual(diff.Reassigned, wantReassigned) {
+		t.Errorf("Reassigned = %+v, want %+v", diff.Reassigned, wantReassigned)
+	}
+}
+
+func TestDiffSnapshotsUnchanged(t *testing.T) {
+	snap := testSnapshot("2026-01-01T00:00:00Z",
+		SnapshotColumn{Title: "Todo", Cards: []SnapshotCard{{ID: 1, Title: "Card"}}},
+	)
+
+	diff := diffSnapshots(snap, snap)
+	if len(diff.Added)+len(diff.Removed)+len(diff.Moved)+len(diff.Retitled)+len(diff.Reassigned) != 0 {
+		t.Errorf("expected no changes, got %+v", diff)
+	}
+
+	if !strings.Contains(formatBoardDiff(diff), "No changes") {
+		t.Error("expected summary to report no changes")
+	}
+}
+
+func TestFormatBoardDiff(t *testing.T) {
+	diff := BoardDiff{
+		BoardTitle: "Sprint",
+		From:       "a",
+		To:         "b",
+		Moved:      []CardMove{{ID: 1, Title: "Login", From: "Todo", To: "Done"}},
+		Reassigned: []CardReassigned{{ID: 2, Title: "API", Added: []string{"Bob"}, Removed: []string{"Jane"}}},
+	}
+
+	got := formatBoardDiff(diff)
+	for _, want := range []string{
+		"Board 'Sprint' changes from a to b",
+		`> #1 "Login" moved Todo -> Done`,
+		`@ #2 "API" reassigned +Bob -Jane`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatBoardDiff() missing %q in:\n%s", want, got)
+		}
+	}
+}
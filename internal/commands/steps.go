@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
+	"github.com/rzolkos/basecamp-cli/internal/config"
 )
 
 // Step represents a card step/checklist item
@@ -31,6 +36,101 @@ type StepOutput struct {
 	Assignees []string `json:"assignees,omitempty"`
 }
 
+func stepToOutput(s Step) StepOutput {
+	var assignees []string
+	for _, a := range s.Assignees {
+		assignees = append(assignees, a.Name)
+	}
+	return StepOutput{
+		ID:        s.ID,
+		Title:     s.Title,
+		Completed: s.Completed,
+		DueOn:     s.DueOn,
+		Position:  s.Position,
+		Assignees: assignees,
+	}
+}
+
+// StepsCmd lists the steps of a card with a completion summary
+type StepsCmd struct{}
+
+type StepsOutput struct {
+	CardID       int          `json:"card_id"`
+	CardTitle    string       `json:"card_title"`
+	Completed    int          `json:"completed"`
+	Total        int          `json:"total"`
+	AllCompleted bool         `json:"all_completed"`
+	Steps        []StepOutput `json:"steps"`
+}
+
+func (c *StepsCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	// Parse card_id and flags
+	var cardID string
+	showCompleted, showPending := false, false
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--completed":
+			showCompleted = true
+		case "--pending":
+			showPending = true
+		default:
+			if cardID == "" {
+				cardID = remaining[i]
+			}
+		}
+	}
+
+	if cardID == "" {
+		return errors.New("usage: basecamp steps [project_id] <card_id> [--completed|--pending]")
+	}
+	if showCompleted && showPending {
+		return errors.New("--completed and --pending cannot be combined")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Get("/buckets/" + projectID + "/card_tables/cards/" + cardID + ".json")
+	if err != nil {
+		return err
+	}
+
+	var card CardDetail
+	if err := json.Unmarshal(data, &card); err != nil {
+		return err
+	}
+
+	output := StepsOutput{
+		CardID:    card.ID,
+		CardTitle: card.Title,
+		Total:     len(card.Steps),
+		Steps:     []StepOutput{},
+	}
+
+	// Counts always cover every step so scripts can check the checklist
+	// is done regardless of which filter was used for the listing
+	for _, s := range card.Steps {
+		if s.Completed {
+			output.Completed++
+		}
+		if (showCompleted && !s.Completed) || (showPending && s.Completed) {
+			continue
+		}
+		output.Steps = append(output.Steps, stepToOutput(s))
+	}
+	output.AllCompleted = output.Completed == output.Total
+
+	return PrintJSON(output)
+}
+
 // StepCreateCmd creates a step in a card
 type StepCreateCmd struct{}
 
@@ -278,6 +378,39 @@ func (c *StepUncompleteCmd) Run(args []string) error {
 	})
 }
 
+// StepDeleteCmd deletes a step by moving it to the trash
+type StepDeleteCmd struct{}
+
+func (c *StepDeleteCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp step-delete [project_id] <step_id>")
+	}
+	stepID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Steps are recordings, so they are deleted through the trash status
+	path := fmt.Sprintf("/buckets/%s/recordings/%s/status/trashed.json", projectID, stepID)
+	_, err = cl.Put(path, nil)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(StepCompleteOutput{
+		Status:  "ok",
+		StepID:  stepID,
+		Message: "Step deleted",
+	})
+}
+
 // StepRepositionCmd changes a step's position
 type StepRepositionCmd struct{}
 
@@ -346,3 +479,185 @@ func (c *StepRepositionCmd) Run(args []string) error {
 		Message:  fmt.Sprintf("Step repositioned to %s", position),
 	})
 }
+
+// StepImportCmd creates steps on a card from a markdown checklist
+type StepImportCmd struct{}
+
+type StepImportOutput struct {
+	Status  string             `json:"status"`
+	CardID  string             `json:"card_id"`
+	Created []StepCreateOutput `json:"created"`
+	Skipped []string           `json:"skipped"`
+	Message string             `json:"message"`
+}
+
+func (c *StepImportCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	// Parse card_id and flags
+	var cardID, file, template string
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--file":
+			if i+1 < len(remaining) {
+				file = remaining[i+1]
+				i++
+			}
+		case "--template":
+			if i+1 < len(remaining) {
+				template = remaining[i+1]
+				i++
+			}
+		default:
+			if cardID == "" {
+				cardID = remaining[i]
+			}
+		}
+	}
+
+	if cardID == "" {
+		return errors.New("card_id required")
+	}
+	if (file == "") == (template == "") {
+		return errors.New("exactly one of --file or --template required")
+	}
+
+	var items []checklistItem
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read checklist: %w", err)
+		}
+		items = parseChecklist(string(data))
+	} else {
+		items, err = loadChecklistTemplate(template)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(items) == 0 {
+		return errors.New("no checklist items found (expected lines like '- [ ] Title')")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Fetch existing steps so re-running the import skips them
+	data, err := cl.Get("/buckets/" + projectID + "/card_tables/cards/" + cardID + ".json")
+	if err != nil {
+		return err
+	}
+
+	var card CardDetail
+	if err := json.Unmarshal(data, &card); err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, s := range card.Steps {
+		existing[normalizeTitle(s.Title)] = true
+	}
+
+	var people []Person
+	for _, item := range items {
+		if len(item.Assignees) > 0 {
+			people, err = fetchPeople(cl)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	output := StepImportOutput{
+		Status:  "ok",
+		CardID:  cardID,
+		Created: []StepCreateOutput{},
+		Skipped: []string{},
+	}
+
+	path := fmt.Sprintf("/buckets/%s/card_tables/cards/%s/steps.json", projectID, cardID)
+
+	for _, item := range items {
+		if existing[normalizeTitle(item.Title)] {
+			output.Skipped = append(output.Skipped, item.Title)
+			continue
+		}
+
+		payload := map[string]any{
+			"title": item.Title,
+		}
+		if item.DueOn != "" {
+			payload["due_on"] = item.DueOn
+		}
+		if len(item.Assignees) > 0 {
+			ids, err := resolvePersonIDs(people, item.Assignees)
+			if err != nil {
+				return fmt.Errorf("step '%s': %w", item.Title, err)
+			}
+			idStrs := make([]string, len(ids))
+			for i, id := range ids {
+				idStrs[i] = strconv.Itoa(id)
+			}
+			payload["assignees"] = strings.Join(idStrs, ",")
+		}
+
+		responseData, err := cl.Post(path, payload)
+		if err != nil {
+			return err
+		}
+
+		var created Step
+		if err := json.Unmarshal(responseData, &created); err != nil {
+			return err
+		}
+
+		if item.Completed {
+			completionPath := fmt.Sprintf("/buckets/%s/card_tables/steps/%d/completions.json", projectID, created.ID)
+			if _, err := cl.Put(completionPath, map[string]string{"completion": "on"}); err != nil {
+				return err
+			}
+		}
+
+		existing[normalizeTitle(item.Title)] = true
+		output.Created = append(output.Created, StepCreateOutput{
+			Status:  "ok",
+			ID:      created.ID,
+			Title:   created.Title,
+			Message: fmt.Sprintf("Step '%s' created", created.Title),
+		})
+	}
+
+	output.Message = fmt.Sprintf("%d steps created, %d skipped", len(output.Created), len(output.Skipped))
+	return PrintJSON(output)
+}
+
+// loadChecklistTemplate reads a named checklist from the config file
+func loadChecklistTemplate(name string) ([]checklistItem, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	lines, ok := cfg.Checklists[name]
+	if !ok {
+		var names []string
+		for n := range cfg.Checklists {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("checklist template '%s' not found: no checklists defined in %s", name, config.ConfigFile())
+		}
+		return nil, fmt.Errorf("checklist template '%s' not found. Available templates: %s", name, strings.Join(names, ", "))
+	}
+
+	return parseChecklist(strings.Join(lines, "\n")), nil
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -44,11 +46,123 @@ type TodoSet struct {
 type Todolist struct {
 	ID             int    `json:"id"`
 	Title          string `json:"title"`
+	Description    string `json:"description"`
 	TodosURL       string `json:"todos_url"`
+	GroupsURL      string `json:"groups_url"`
 	CompletedRatio string `json:"completed_ratio"`
 	Completed      bool   `json:"completed"`
 }
 
+// fetchTodolists gets every todolist in a project's todoset
+func fetchTodolists(cl *client.Client, projectID string) (TodoSet, []Todolist, error) {
+	_, todoset, err := fetchTodoSet(cl, projectID)
+	if err != nil {
+		return TodoSet{}, nil, err
+	}
+
+	data, err := cl.GetAll(todoset.TodolistsURL)
+	if err != nil {
+		return TodoSet{}, nil, err
+	}
+
+	todolists := make([]Todolist, len(data))
+	for i, tlJSON := range data {
+		if err := json.Unmarshal(tlJSON, &todolists[i]); err != nil {
+			return TodoSet{}, nil, err
+		}
+	}
+	return todoset, todolists, nil
+}
+
+// matchByName picks the one name matching ref exactly (case-insensitive),
+// or failing that the one containing it. kind is used in error messages.
+func matchByName(kind, ref string, names []string) (int, error) {
+	for _, exact := range []bool{true, false} {
+		var found []int
+		for i, name := range names {
+			if (exact && strings.EqualFold(name, ref)) ||
+				(!exact && strings.Contains(strings.ToLower(name), strings.ToLower(ref))) {
+				found = append(found, i)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], nil
+		}
+		if len(found) > 1 {
+			matches := make([]string, len(found))
+			for i, idx := range found {
+				matches[i] = names[idx]
+			}
+			return -1, fmt.Errorf("%s '%s' is ambiguous: %s", kind, ref, strings.Join(matches, ", "))
+		}
+	}
+	return -1, fmt.Errorf("%s '%s' not found. Available: %s", kind, ref, strings.Join(names, ", "))
+}
+
+// resolveTodolistID returns ref if it is numeric, otherwise the ID of the
+// todolist whose name matches it
+func resolveTodolistID(cl *client.Client, projectID, ref string) (string, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return ref, nil
+	}
+
+	_, todolists, err := fetchTodolists(cl, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(todolists))
+	for i, tl := range todolists {
+		names[i] = tl.Title
+	}
+
+	idx, err := matchByName("todolist", ref, names)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(todolists[idx].ID), nil
+}
+
+// fetchTodolistGroups gets the groups in a todolist
+func fetchTodolistGroups(cl *client.Client, projectID, todolistID string) ([]TodolistGroup, error) {
+	data, err := cl.GetAll(fmt.Sprintf("/buckets/%s/todolists/%s/groups.json", projectID, todolistID))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]TodolistGroup, len(data))
+	for i, groupJSON := range data {
+		if err := json.Unmarshal(groupJSON, &groups[i]); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// resolveTodolistGroupID returns ref if it is numeric, otherwise the ID of
+// the group in todolistID whose name matches it
+func resolveTodolistGroupID(cl *client.Client, projectID, todolistID, ref string) (string, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return ref, nil
+	}
+
+	groups, err := fetchTodolistGroups(cl, projectID, todolistID)
+	if err != nil {
+		return "", err
+	}
+
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.Name
+	}
+
+	idx, err := matchByName("group", ref, names)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(groups[idx].ID), nil
+}
+
 type TodolistOutput struct {
 	ID             int    `json:"id"`
 	Title          string `json:"title"`
@@ -107,6 +221,166 @@ func (c *TodolistsCmd) Run(args []string) error {
 	return PrintJSON(output)
 }
 
+// TodolistCreateCmd creates a new todolist in a project's todoset
+type TodolistCreateCmd struct{}
+
+type TodolistWriteOutput struct {
+	Status  string `json:"status"`
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+func (c *TodolistCreateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	// Parse flags
+	var name, description string
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--name":
+			if i+1 < len(remaining) {
+				name = remaining[i+1]
+				i++
+			}
+		case "--description":
+			if i+1 < len(remaining) {
+				description = remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	if name == "" {
+		return errors.New("--name required")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, todoset, err := fetchTodoSet(cl, projectID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"name": name,
+	}
+	if description != "" {
+		payload["description"] = description
+	}
+
+	data, err := cl.Post(todoset.TodolistsURL, payload)
+	if err != nil {
+		return err
+	}
+
+	var created Todolist
+	if err := json.Unmarshal(data, &created); err != nil {
+		return err
+	}
+
+	return PrintJSON(TodolistWriteOutput{
+		Status:  "ok",
+		ID:      created.ID,
+		Title:   created.Title,
+		Message: fmt.Sprintf("Todolist '%s' created", created.Title),
+	})
+}
+
+// TodolistUpdateCmd renames a todolist or changes its description
+type TodolistUpdateCmd struct{}
+
+func (c *TodolistUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp todolist-update [project_id] <todolist> [--name <name>] [--description <text>]")
+	}
+	todolistRef := remaining[0]
+
+	// Parse flags
+	var name, description *string
+
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--name":
+			if i+1 < len(remaining) {
+				name = &remaining[i+1]
+				i++
+			}
+		case "--description":
+			if i+1 < len(remaining) {
+				description = &remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	if name == nil && description == nil {
+		return errors.New("at least one of --name or --description required")
+	}
+	if name != nil && *name == "" {
+		return errors.New("--name cannot be empty")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	todolistID, err := resolveTodolistID(cl, projectID, todolistRef)
+	if err != nil {
+		return err
+	}
+
+	// The name is required on update, so keep the current one if not given
+	path := "/buckets/" + projectID + "/todolists/" + todolistID + ".json"
+	data, err := cl.Get(path)
+	if err != nil {
+		return err
+	}
+
+	var current Todolist
+	if err := json.Unmarshal(data, &current); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"name":        coalesce(stringValue(name), current.Title),
+		"description": current.Description,
+	}
+	if description != nil {
+		payload["description"] = *description
+	}
+
+	data, err = cl.Put(path, payload)
+	if err != nil {
+		return err
+	}
+
+	var updated Todolist
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return err
+	}
+
+	return PrintJSON(TodolistWriteOutput{
+		Status:  "ok",
+		ID:      updated.ID,
+		Title:   updated.Title,
+		Message: fmt.Sprintf("Todolist '%s' updated", updated.Title),
+	})
+}
+
 // TodolistGroup represents a group of todolists
 type TodolistGroup struct {
 	ID        int    `json:"id"`
@@ -115,6 +389,7 @@ type TodolistGroup struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	Color     string `json:"color"`
+	TodosURL  string `json:"todos_url"`
 }
 
 // TodolistGroupsCmd lists todolist groups within a todolist
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchByName(t *testing.T) {
+	names := []string{"Launch", "Launch v2", "Marketing", "Bugs"}
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    int
+		wantErr string
+	}{
+		{name: "exact", ref: "Launch", want: 0},
+		{name: "exact case-insensitive", ref: "marketing", want: 2},
+		{name: "exact wins over substring", ref: "launch", want: 0},
+		{name: "unique substring", ref: "bug", want: 3},
+		{name: "ambiguous substring", ref: "aun", wantErr: "todolist 'aun' is ambiguous: Launch, Launch v2"},
+		{name: "not found", ref: "Design", wantErr: "todolist 'Design' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchByName("todolist", tt.ref, names)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("matchByName(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchByName(%q) unexpected error: %v", tt.ref, err)
+			}
+			if got != tt.want {
+				t.Errorf("matchByName(%q) = %d, want %d", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,15 +12,18 @@ import (
 type TodosCmd struct{}
 
 type Todo struct {
-	ID          int        `json:"id"`
-	Content     string     `json:"content"`
-	Description string     `json:"description"`
-	Completed   bool       `json:"completed"`
-	DueOn       string     `json:"due_on"`
-	StartsOn    string     `json:"starts_on"`
-	Creator     Creator    `json:"creator"`
-	Assignees   []Assignee `json:"assignees"`
-	CommentsURL string     `json:"comments_url"`
+	ID                    int        `json:"id"`
+	Content               string     `json:"content"`
+	Description           string     `json:"description"`
+	Completed             bool       `json:"completed"`
+	DueOn                 string     `json:"due_on"`
+	StartsOn              string     `json:"starts_on"`
+	Creator               Creator    `json:"creator"`
+	Assignees             []Assignee `json:"assignees"`
+	CompletionSubscribers []Assignee `json:"completion_subscribers"`
+	CommentsURL           string     `json:"comments_url"`
+	AppURL                string     `json:"app_url"`
+	UpdatedAt             string     `json:"updated_at"`
 }
 
 type TodoOutput struct {
@@ -116,6 +119,7 @@ type TodoDetailOutput struct {
 	StartsOn    string   `json:"starts_on,omitempty"`
 	Creator     string   `json:"creator"`
 	Assignees   []string `json:"assignees,omitempty"`
+	Subscribers []string `json:"subscribers,omitempty"`
 }
 
 func (c *TodoCmd) Run(args []string) error {
@@ -125,10 +129,20 @@ func (c *TodoCmd) Run(args []string) error {
 	}
 
 	if len(remaining) < 1 {
-		return errors.New("usage: basecamp todo [project_id] <todo_id>")
+		return errors.New("usage: basecamp todo [project_id] <todo_id> [--body markdown|text|html]")
 	}
 	todoID := remaining[0]
 
+	body := bodyMarkdown
+	for i := 1; i < len(remaining); i++ {
+		if remaining[i] == "--body" && i+1 < len(remaining) {
+			if body, err = parseBodyFormat(remaining[i+1]); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+
 	cl, err := client.New()
 	if err != nil {
 		return err
@@ -149,15 +163,21 @@ func (c *TodoCmd) Run(args []string) error {
 		assignees = append(assignees, a.Name)
 	}
 
+	var subscribers []string
+	for _, s := range todo.CompletionSubscribers {
+		subscribers = append(subscribers, s.Name)
+	}
+
 	output := TodoDetailOutput{
 		ID:          todo.ID,
 		Content:     stripHTML(todo.Content),
-		Description: stripHTML(todo.Description),
+		Description: renderBody(body, todo.Description),
 		Completed:   todo.Completed,
 		DueOn:       todo.DueOn,
 		StartsOn:    todo.StartsOn,
 		Creator:     todo.Creator.Name,
 		Assignees:   assignees,
+		Subscribers: subscribers,
 	}
 
 	return PrintJSON(output)
@@ -172,11 +192,13 @@ func (c *TodoCreateCmd) Run(args []string) error {
 	}
 
 	if len(remaining) < 1 {
-		return errors.New("usage: basecamp todo-create [project_id] <todolist_id> --content <text> [--due <date>] [--description <text>]")
+		return errors.New("usage: basecamp todo-create [project_id] <todolist_id> --content <text> [--due <date>] [--starts <date>] [--description <text>] [--markdown] [--assignees <people>] [--subscribers <people>] [--notify|--no-notify]")
 	}
 	todolistID := remaining[0]
 
-	var content, description, dueOn, assignees string
+	var content, description, dueOn, startsOn, assignees, subscribers string
+	var notify *bool
+	var md markdownFlags
 	for i := 1; i < len(remaining); i++ {
 		switch remaining[i] {
 		case "--content":
@@ -194,11 +216,27 @@ func (c *TodoCreateCmd) Run(args []string) error {
 				dueOn = remaining[i+1]
 				i++
 			}
+		case "--starts":
+			if i+1 < len(remaining) {
+				startsOn = remaining[i+1]
+				i++
+			}
 		case "--assignees":
 			if i+1 < len(remaining) {
 				assignees = remaining[i+1]
 				i++
 			}
+		case "--subscribers":
+			if i+1 < len(remaining) {
+				subscribers = remaining[i+1]
+				i++
+			}
+		case "--notify":
+			notify = boolPtr(true)
+		case "--no-notify":
+			notify = boolPtr(false)
+		default:
+			md.parse(remaining[i])
 		}
 	}
 
@@ -215,24 +253,34 @@ func (c *TodoCreateCmd) Run(args []string) error {
 		"content": content,
 	}
 	if description != "" {
-		payload["description"] = description
+		payload["description"] = md.convert(description)
 	}
 	if dueOn != "" {
 		payload["due_on"] = dueOn
 	}
+	if startsOn != "" {
+		payload["starts_on"] = startsOn
+	}
 	if assignees != "" {
-		var assigneeIDs []int
-		for _, idStr := range strings.Split(assignees, ",") {
-			idStr = strings.TrimSpace(idStr)
-			if id, err := strconv.Atoi(idStr); err == nil {
-				assigneeIDs = append(assigneeIDs, id)
-			}
+		assigneeIDs, err := resolvePeopleList(cl, assignees)
+		if err != nil {
+			return err
 		}
 		if len(assigneeIDs) > 0 {
 			payload["assignee_ids"] = assigneeIDs
 			payload["notify"] = true
 		}
 	}
+	if subscribers != "" {
+		subscriberIDs, err := resolvePeopleList(cl, subscribers)
+		if err != nil {
+			return err
+		}
+		payload["completion_subscriber_ids"] = subscriberIDs
+	}
+	if notify != nil {
+		payload["notify"] = *notify
+	}
 
 	data, err := cl.Post("/buckets/"+projectID+"/todolists/"+todolistID+"/todos.json", payload)
 	if err != nil {
@@ -252,6 +300,315 @@ func (c *TodoCreateCmd) Run(args []string) error {
 	})
 }
 
+// TodoUpdateCmd updates a todo. Basecamp clears any attribute left out of
+// an update, so the current todo is fetched and only the given flags change.
+type TodoUpdateCmd struct{}
+
+func (c *TodoUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp todo-update [project_id] <todo_id> [--content <text>] [--description <text>] [--markdown] [--due <date>] [--starts <date>] [--assignees <people>] [--subscribers <people>] [--clear-due] [--clear-starts] [--clear-assignees] [--clear-subscribers] [--notify|--no-notify]")
+	}
+	todoID := remaining[0]
+
+	// Pointers distinguish "not given" from "set to empty"
+	var content, description, dueOn, startsOn, assignees, subscribers *string
+	var notify *bool
+	var md markdownFlags
+	empty := ""
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--content":
+			if i+1 < len(remaining) {
+				content = &remaining[i+1]
+				i++
+			}
+		case "--description":
+			if i+1 < len(remaining) {
+				description = &remaining[i+1]
+				i++
+			}
+		case "--due":
+			if i+1 < len(remaining) {
+				dueOn = &remaining[i+1]
+				i++
+			}
+		case "--starts":
+			if i+1 < len(remaining) {
+				startsOn = &remaining[i+1]
+				i++
+			}
+		case "--assignees":
+			if i+1 < len(remaining) {
+				assignees = &remaining[i+1]
+				i++
+			}
+		case "--subscribers":
+			if i+1 < len(remaining) {
+				subscribers = &remaining[i+1]
+				i++
+			}
+		case "--clear-due":
+			dueOn = &empty
+		case "--clear-starts":
+			startsOn = &empty
+		case "--clear-assignees":
+			assignees = &empty
+		case "--clear-subscribers":
+			subscribers = &empty
+		case "--notify":
+			notify = boolPtr(true)
+		case "--no-notify":
+			notify = boolPtr(false)
+		default:
+			md.parse(remaining[i])
+		}
+	}
+
+	if content == nil && description == nil && dueOn == nil && startsOn == nil && assignees == nil && subscribers == nil {
+		return errors.New("at least one of --content, --description, --due, --starts, --assignees, --subscribers or a --clear-* flag required")
+	}
+	if content != nil && *content == "" {
+		return errors.New("--content cannot be empty")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	path := "/buckets/" + projectID + "/todos/" + todoID + ".json"
+	data, err := cl.Get(path)
+	if err != nil {
+		return err
+	}
+
+	var current Todo
+	if err := json.Unmarshal(data, &current); err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"content":                   coalesce(stringValue(content), current.Content),
+		"description":               current.Description,
+		"due_on":                    current.DueOn,
+		"starts_on":                 current.StartsOn,
+		"assignee_ids":              assigneeIDs(current.Assignees),
+		"completion_subscriber_ids": assigneeIDs(current.CompletionSubscribers),
+	}
+	if description != nil {
+		payload["description"] = md.convert(*description)
+	}
+	if dueOn != nil {
+		payload["due_on"] = *dueOn
+	}
+	if startsOn != nil {
+		payload["starts_on"] = *startsOn
+	}
+	if assignees != nil {
+		ids := []int{}
+		if *assignees != "" {
+			if ids, err = resolvePeopleList(cl, *assignees); err != nil {
+				return err
+			}
+		}
+		payload["assignee_ids"] = ids
+	}
+	if subscribers != nil {
+		ids := []int{}
+		if *subscribers != "" {
+			if ids, err = resolvePeopleList(cl, *subscribers); err != nil {
+				return err
+			}
+		}
+		payload["completion_subscriber_ids"] = ids
+	}
+	if notify != nil {
+		payload["notify"] = *notify
+	}
+
+	data, err = cl.Put(path, payload)
+	if err != nil {
+		return err
+	}
+
+	var todo Todo
+	if err := json.Unmarshal(data, &todo); err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":  "ok",
+		"id":      todo.ID,
+		"content": stripHTML(todo.Content),
+		"message": "Todo updated",
+	})
+}
+
+// TodoTrashCmd moves a todo to the trash
+type TodoTrashCmd struct{}
+
+func (c *TodoTrashCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp todo-trash [project_id] <todo_id>")
+	}
+	todoID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.Put("/buckets/"+projectID+"/recordings/"+todoID+"/status/trashed.json", nil)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":  "ok",
+		"todo_id": todoID,
+		"message": "Todo trashed",
+	})
+}
+
+// TodoMoveCmd moves a todo to another todolist or group
+type TodoMoveCmd struct{}
+
+func (c *TodoMoveCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp todo-move [project_id] <todo_id> --to-list <id|name> [--group <id|name>] [--position <n>]")
+	}
+	todoID := remaining[0]
+
+	var toList, group, position string
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--to-list":
+			if i+1 < len(remaining) {
+				toList = remaining[i+1]
+				i++
+			}
+		case "--group":
+			if i+1 < len(remaining) {
+				group = remaining[i+1]
+				i++
+			}
+		case "--position":
+			if i+1 < len(remaining) {
+				position = remaining[i+1]
+				i++
+			}
+		}
+	}
+
+	if toList == "" {
+		return errors.New("--to-list required (todolist ID or name)")
+	}
+
+	pos := 1
+	if position != "" {
+		if pos, err = strconv.Atoi(position); err != nil || pos < 1 {
+			return errors.New("--position must be a number (1-indexed)")
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	todolistID, err := resolveTodolistID(cl, projectID, toList)
+	if err != nil {
+		return err
+	}
+
+	// Groups are todolists too, so the todo's new parent is either
+	parentID := todolistID
+	if group != "" {
+		parentID, err = resolveTodolistGroupID(cl, projectID, todolistID, group)
+		if err != nil {
+			return err
+		}
+	}
+
+	parent, _ := strconv.Atoi(parentID)
+	payload := map[string]any{
+		"position":  pos,
+		"parent_id": parent,
+	}
+
+	_, err = cl.Put("/buckets/"+projectID+"/todos/"+todoID+"/position.json", payload)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":    "ok",
+		"todo_id":   todoID,
+		"parent_id": parent,
+		"position":  pos,
+		"message":   "Todo moved to " + parentID,
+	})
+}
+
+// fetchTodos gets the open todos at todosURL, plus the completed ones if
+// includeCompleted is set
+func fetchTodos(cl *client.Client, todosURL string, includeCompleted bool) ([]Todo, error) {
+	urls := []string{todosURL}
+	if includeCompleted {
+		urls = append(urls, todosURL+"?completed=true")
+	}
+
+	var todos []Todo
+	for _, url := range urls {
+		data, err := cl.GetAll(url)
+		if err != nil {
+			return nil, err
+		}
+		for _, todoJSON := range data {
+			var todo Todo
+			if err := json.Unmarshal(todoJSON, &todo); err != nil {
+				return nil, err
+			}
+			todos = append(todos, todo)
+		}
+	}
+	return todos, nil
+}
+
+func assigneeIDs(assignees []Assignee) []int {
+	ids := make([]int, len(assignees))
+	for i, a := range assignees {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 type TodoCompleteCmd struct{}
 
 func (c *TodoCompleteCmd) Run(args []string) error {
@@ -0,0 +1,354 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+type TodosExportCmd struct{}
+
+// exportTodolist is a todolist with all its todos, open and completed
+type exportTodolist struct {
+	Todolist Todolist
+	Todos    []Todo
+	Groups   []exportGroup
+}
+
+type exportGroup struct {
+	Group TodolistGroup
+	Todos []Todo
+}
+
+type TodosExportOutput struct {
+	Status    string `json:"status"`
+	File      string `json:"file"`
+	Format    string `json:"format"`
+	Todolists int    `json:"todolists"`
+	Todos     int    `json:"todos"`
+	Message   string `json:"message"`
+}
+
+func (l exportTodolist) count() int {
+	n := len(l.Todos)
+	for _, g := range l.Groups {
+		n += len(g.Todos)
+	}
+	return n
+}
+
+func fetchTodolistExport(cl *client.Client, projectID string, todolist Todolist) (exportTodolist, error) {
+	export := exportTodolist{Todolist: todolist}
+
+	todos, err := fetchTodos(cl, todolist.TodosURL, true)
+	if err != nil {
+		return exportTodolist{}, err
+	}
+	export.Todos = todos
+
+	groups, err := fetchTodolistGroups(cl, projectID, strconv.Itoa(todolist.ID))
+	if err != nil {
+		return exportTodolist{}, err
+	}
+
+	for _, group := range groups {
+		todos, err := fetchTodos(cl, group.TodosURL, true)
+		if err != nil {
+			return exportTodolist{}, err
+		}
+		export.Groups = append(export.Groups, exportGroup{Group: group, Todos: todos})
+	}
+
+	return export, nil
+}
+
+// markdownAssignee renders a person as an @token that todos-import resolves
+// back to the same person
+func markdownAssignee(a Assignee) string {
+	return "@" + strings.ReplaceAll(a.Name, " ", "")
+}
+
+func writeMarkdownTodos(b *strings.Builder, todos []Todo) {
+	for _, todo := range todos {
+		check := " "
+		if todo.Completed {
+			check = "x"
+		}
+
+		parts := []string{fmt.Sprintf("- [%s] %s", check, strings.Join(strings.Fields(todo.Content), " "))}
+		for _, a := range todo.Assignees {
+			parts = append(parts, markdownAssignee(a))
+		}
+		if todo.StartsOn != "" {
+			parts = append(parts, "starts:"+todo.StartsOn)
+		}
+		if todo.DueOn != "" {
+			parts = append(parts, "due:"+todo.DueOn)
+		}
+		b.WriteString(strings.Join(parts, " ") + "\n")
+
+		if description := htmlToText(todo.Description); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+	}
+}
+
+// renderTodosMarkdown renders todolists in the format todos-import reads.
+// With a single list the title is left out so that importing the output
+// into a list recreates the same groups. Several lists get "# Title"
+// headings, which todos-import --all turns back into todolists.
+func renderTodosMarkdown(lists []exportTodolist) string {
+	var b strings.Builder
+
+	for i, list := range lists {
+		if len(lists) > 1 {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("# " + list.Todolist.Title + "\n\n")
+		}
+
+		writeMarkdownTodos(&b, list.Todos)
+
+		for _, group := range list.Groups {
+			b.WriteString("\n## " + group.Group.Name + "\n\n")
+			writeMarkdownTodos(&b, group.Todos)
+		}
+	}
+
+	return b.String()
+}
+
+// renderTodosCSV renders todolists with one row per todo, using the column
+// names todos-import recognizes
+func renderTodosCSV(lists []exportTodolist) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+
+	if err := w.Write([]string{"id", "todolist", "group", "content", "completed", "assignees", "starts", "due", "description"}); err != nil {
+		return "", err
+	}
+
+	writeRows := func(list, group string, todos []Todo) error {
+		for _, todo := range todos {
+			names := make([]string, len(todo.Assignees))
+			for i, a := range todo.Assignees {
+				names[i] = a.Name
+			}
+			err := w.Write([]string{
+				strconv.Itoa(todo.ID),
+				list,
+				group,
+				todo.Content,
+				strconv.FormatBool(todo.Completed),
+				strings.Join(names, "; "),
+				todo.StartsOn,
+				todo.DueOn,
+				htmlToText(todo.Description),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, list := range lists {
+		if err := writeRows(list.Todolist.Title, "", list.Todos); err != nil {
+			return "", err
+		}
+		for _, group := range list.Groups {
+			if err := writeRows(list.Todolist.Title, group.Group.Name, group.Todos); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+func writeICSTodo(b *strings.Builder, todo Todo, category string, now time.Time) {
+	writeICSLine(b, "BEGIN:VTODO")
+	writeICSLine(b, fmt.Sprintf("UID:basecamp-todo-%d", todo.ID))
+	writeICSLine(b, "DTSTAMP:"+icsTimestamp(todo.UpdatedAt, now))
+	writeICSLine(b, "SUMMARY:"+icsEscape(todo.Content))
+
+	var description []string
+	if len(todo.Assignees) > 0 {
+		names := make([]string, len(todo.Assignees))
+		for i, a := range todo.Assignees {
+			names[i] = a.Name
+		}
+		description = append(description, "Assigned to: "+strings.Join(names, ", "))
+	}
+	if text := htmlToText(todo.Description); text != "" {
+		description = append(description, text)
+	}
+	if len(description) > 0 {
+		writeICSLine(b, "DESCRIPTION:"+icsEscape(strings.Join(description, "\n\n")))
+	}
+
+	// DUE must come after DTSTART, so a todo that starts on its due date
+	// is only given the due date
+	if todo.StartsOn != "" && (todo.DueOn == "" || todo.StartsOn < todo.DueOn) {
+		writeICSLine(b, "DTSTART;VALUE=DATE:"+icsDate(todo.StartsOn))
+	}
+	if todo.DueOn != "" {
+		writeICSLine(b, "DUE;VALUE=DATE:"+icsDate(todo.DueOn))
+	}
+	if todo.Completed {
+		writeICSLine(b, "STATUS:COMPLETED")
+		writeICSLine(b, "PERCENT-COMPLETE:100")
+	} else {
+		writeICSLine(b, "STATUS:NEEDS-ACTION")
+	}
+	writeICSLine(b, "CATEGORIES:"+icsEscape(category))
+	if todo.AppURL != "" {
+		writeICSLine(b, "URL:"+todo.AppURL)
+	}
+	writeICSLine(b, "END:VTODO")
+}
+
+// renderTodosICS renders todolists as an iCalendar file of VTODOs. UIDs
+// are derived from the todo IDs so re-importing the file in a calendar
+// client updates rather than duplicates them.
+func renderTodosICS(lists []exportTodolist, now time.Time) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//basecamp-cli//todos-export//EN")
+
+	for _, list := range lists {
+		for _, todo := range list.Todos {
+			writeICSTodo(&b, todo, list.Todolist.Title, now)
+		}
+		for _, group := range list.Groups {
+			for _, todo := range group.Todos {
+				writeICSTodo(&b, todo, list.Todolist.Title+" / "+group.Group.Name, now)
+			}
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+func (c *TodosExportCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var todolistRef, out string
+	format := "md"
+	var all bool
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--format":
+			if i+1 < len(remaining) {
+				format = remaining[i+1]
+				i++
+			}
+		case "--out":
+			if i+1 < len(remaining) {
+				out = remaining[i+1]
+				i++
+			}
+		case "--all":
+			all = true
+		default:
+			if todolistRef == "" {
+				todolistRef = remaining[i]
+			}
+		}
+	}
+
+	if (todolistRef == "") == !all {
+		return errors.New("usage: basecamp todos-export [project_id] <todolist|--all> [--format md|csv|ics] [--out <file>]")
+	}
+	if format != "md" && format != "csv" && format != "ics" {
+		return errors.New("--format must be md, csv or ics")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	var todolists []Todolist
+	if all {
+		_, todolists, err = fetchTodolists(cl, projectID)
+		if err != nil {
+			return err
+		}
+	} else {
+		todolistID, err := resolveTodolistID(cl, projectID, todolistRef)
+		if err != nil {
+			return err
+		}
+
+		data, err := cl.Get("/buckets/" + projectID + "/todolists/" + todolistID + ".json")
+		if err != nil {
+			return err
+		}
+
+		var todolist Todolist
+		if err := json.Unmarshal(data, &todolist); err != nil {
+			return err
+		}
+		todolists = []Todolist{todolist}
+	}
+
+	lists := make([]exportTodolist, len(todolists))
+	total := 0
+	for i, todolist := range todolists {
+		lists[i], err = fetchTodolistExport(cl, projectID, todolist)
+		if err != nil {
+			return err
+		}
+		total += lists[i].count()
+	}
+
+	var rendered string
+	switch format {
+	case "md":
+		rendered = renderTodosMarkdown(lists)
+	case "csv":
+		rendered, err = renderTodosCSV(lists)
+		if err != nil {
+			return err
+		}
+	case "ics":
+		rendered = renderTodosICS(lists, time.Now())
+	}
+
+	// Without --out the export itself is the output
+	if out == "" {
+		fmt.Print(rendered)
+		return nil
+	}
+
+	if err := os.WriteFile(out, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return PrintJSON(TodosExportOutput{
+		Status:    "ok",
+		File:      out,
+		Format:    format,
+		Todolists: len(lists),
+		Todos:     total,
+		Message:   fmt.Sprintf("%d todos from %d todolists written to %s", total, len(lists), out),
+	})
+}
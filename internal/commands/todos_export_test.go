@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleExport() []exportTodolist {
+	return []exportTodolist{{
+		Todolist: Todolist{ID: 1, Title: "Launch"},
+		Todos: []Todo{
+			{
+				ID:          10,
+				Content:     "Kickoff meeting",
+				Description: "<div>Agenda is in the <strong>shared</strong> doc.<br><br>Bring laptops &amp; chargers.</div>",
+				DueOn:       "2026-02-02",
+				Assignees:   []Assignee{{ID: 1, Name: "Jane Doe"}},
+			},
+		},
+		Groups: []exportGroup{{
+			Group: TodolistGroup{ID: 2, Name: "Week 1"},
+			Todos: []Todo{
+				{ID: 11, Content: "Set up repo", Completed: true},
+				{ID: 12, Content: "Write spec", StartsOn: "2026-02-03", DueOn: "2026-02-06", Assignees: []Assignee{{ID: 2, Name: "Bob"}}},
+			},
+		}},
+	}}
+}
+
+func TestRenderTodosMarkdownRoundTrip(t *testing.T) {
+	md := renderTodosMarkdown(sampleExport())
+
+	want := `- [ ] Kickoff meeting @JaneDoe due:2026-02-02
+  Agenda is in the shared doc.
+  
+  Bring laptops & chargers.
+
+## Week 1
+
+- [x] Set up repo
+- [ ] Write spec @Bob starts:2026-02-03 due:2026-02-06
+`
+	if md != want {
+		t.Fatalf("renderTodosMarkdown() =\n%s\nwant\n%s", md, want)
+	}
+
+	sections := parseChecklistSections(md)
+	wantSections := []checklistSection{
+		{Items: []checklistItem{{
+			Title:       "Kickoff meeting",
+			Assignees:   []string{"JaneDoe"},
+			DueOn:       "2026-02-02",
+			Description: "Agenda is in the shared doc.\n\nBring laptops & chargers.",
+		}}},
+		{Heading: "Week 1", Items: []checklistItem{
+			{Title: "Set up repo", Completed: true},
+			{Title: "Write spec", Assignees: []string{"Bob"}, StartsOn: "2026-02-03", DueOn: "2026-02-06"},
+		}},
+	}
+	if !reflect.DeepEqual(sections, wantSections) {
+		t.Errorf("round trip = %+v, want %+v", sections, wantSections)
+	}
+}
+
+func TestRenderTodosMarkdownMultipleLists(t *testing.T) {
+	lists := append(sampleExport(), exportTodolist{
+		Todolist: Todolist{ID: 3, Title: "Bugs"},
+		Todos:    []Todo{{ID: 13, Content: "Fix login"}},
+	})
+
+	md := renderTodosMarkdown(lists)
+	for _, want := range []string{"# Launch\n", "\n## Week 1\n", "# Bugs\n\n- [ ] Fix login\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in output:\n%s", want, md)
+		}
+	}
+
+	// todos-import --all reads the lists and their groups back
+	type key struct{ list, group string }
+	var got []key
+	var titles []string
+	for _, section := range parseTodolistSections(md) {
+		got = append(got, key{section.List, section.Heading})
+		for _, item := range section.Items {
+			titles = append(titles, item.Title)
+		}
+	}
+	want := []key{{"Launch", ""}, {"Launch", "Week 1"}, {"Bugs", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip sections = %v, want %v", got, want)
+	}
+	if wantTitles := []string{"Kickoff meeting", "Set up repo", "Write spec", "Fix login"}; !reflect.DeepEqual(titles, wantTitles) {
+		t.Errorf("round trip todos = %q, want %q", titles, wantTitles)
+	}
+
+	// and so does the CSV export, by its todolist column
+	out, err := renderTodosCSV(lists)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sections, err := parseTodoCSV(strings.NewReader(out), map[string]string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = nil
+	for _, section := range sections {
+		got = append(got, key{section.List, section.Heading})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CSV round trip sections = %v, want %v", got, want)
+	}
+}
+
+func TestRenderTodosCSVRoundTrip(t *testing.T) {
+	out, err := renderTodosCSV(sampleExport())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(out, "id,todolist,group,content,completed,assignees,starts,due,description\n") {
+		t.Errorf("unexpected header in:\n%s", out)
+	}
+
+	sections, err := parseTodoCSV(strings.NewReader(out), map[string]string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sections) != 2 || sections[1].Heading != "Week 1" {
+		t.Fatalf("unexpected sections: %+v", sections)
+	}
+
+	kickoff := sections[0].Items[0]
+	if kickoff.Title != "Kickoff meeting" || !reflect.DeepEqual(kickoff.Assignees, []string{"Jane Doe"}) {
+		t.Errorf("unexpected kickoff: %+v", kickoff)
+	}
+	if !sections[1].Items[0].Completed || sections[1].Items[1].Completed {
+		t.Error("completion state did not round trip")
+	}
+	if sections[1].Items[1].StartsOn != "2026-02-03" || sections[1].Items[1].DueOn != "2026-02-06" {
+		t.Errorf("dates did not round trip: %+v", sections[1].Items[1])
+	}
+}
+
+func TestRenderTodosICS(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ics := renderTodosICS(sampleExport(), now)
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:basecamp-todo-10\r\n",
+		"DTSTAMP:20260101T120000Z\r\n",
+		"DUE;VALUE=DATE:20260202\r\n",
+		"DTSTART;VALUE=DATE:20260203\r\n",
+		"STATUS:COMPLETED\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"CATEGORIES:Launch / Week 1\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected %q in output:\n%s", want, ics)
+		}
+	}
+
+	if strings.Count(ics, "BEGIN:VTODO") != 3 {
+		t.Errorf("expected 3 VTODOs, got %d", strings.Count(ics, "BEGIN:VTODO"))
+	}
+
+	// A todo starting on its due date has no DTSTART, as DUE must be later
+	sameDay := []exportTodolist{{Todolist: Todolist{Title: "Launch"}, Todos: []Todo{{ID: 14, Content: "Demo", StartsOn: "2026-02-10", DueOn: "2026-02-10"}}}}
+	ics = renderTodosICS(sameDay, now)
+	if strings.Contains(ics, "DTSTART") || !strings.Contains(ics, "DUE;VALUE=DATE:20260210\r\n") {
+		t.Errorf("unexpected same-day todo:\n%s", ics)
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "<div>Hello <em>world</em></div>", want: "Hello world"},
+		{in: "<div>One<br>Two</div><div>Three</div>", want: "One\nTwo\nThree"},
+		{in: "<ul><li>A</li><li>B</li></ul>", want: "- A\n- B"},
+		{in: "a<br><br><br><br>b", want: "a\n\nb"},
+		{in: "Fish &amp; chips", want: "Fish & chips"},
+	}
+
+	for _, tt := range tests {
+		if got := htmlToText(tt.in); got != tt.want {
+			t.Errorf("htmlToText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
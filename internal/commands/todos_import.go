@@ -0,0 +1,484 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+type TodosImportCmd struct{}
+
+type TodoImportResult struct {
+	ID        int    `json:"id,omitempty"`
+	Todolist  string `json:"todolist,omitempty"`
+	Content   string `json:"content"`
+	Group     string `json:"group,omitempty"`
+	Completed bool   `json:"completed"`
+}
+
+type TodosImportOutput struct {
+	Status           string             `json:"status"`
+	TodolistID       string             `json:"todolist_id,omitempty"`
+	DryRun           bool               `json:"dry_run"`
+	TodolistsCreated []string           `json:"todolists_created,omitempty"`
+	GroupsCreated    []string           `json:"groups_created"`
+	Created          []TodoImportResult `json:"created"`
+	Skipped          []string           `json:"skipped"`
+	Message          string             `json:"message"`
+}
+
+// csvFieldAliases are the column headers recognized for each field when
+// no --map is given
+var csvFieldAliases = map[string][]string{
+	"title":       {"title", "content", "todo", "task", "name"},
+	"todolist":    {"todolist", "list"},
+	"group":       {"group", "section"},
+	"assignees":   {"assignees", "assignee", "owner"},
+	"due":         {"due", "due_on", "due date"},
+	"starts":      {"starts", "starts_on", "start", "start date"},
+	"completed":   {"completed", "done", "status"},
+	"description": {"description", "notes", "details"},
+}
+
+// parseCSVMapping parses "field=Header,field=Header" into a field to
+// header map
+func parseCSVMapping(s string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if s == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || strings.TrimSpace(header) == "" {
+			return nil, fmt.Errorf("invalid mapping '%s' (expected field=Header)", pair)
+		}
+		if _, known := csvFieldAliases[field]; !known {
+			return nil, fmt.Errorf("unknown field '%s' in mapping", field)
+		}
+		mapping[field] = strings.TrimSpace(header)
+	}
+	return mapping, nil
+}
+
+// parseTodoCSV reads todos from CSV, one per row, grouping rows by their
+// todolist and group columns in order of first appearance
+func parseTodoCSV(r io.Reader, mapping map[string]string) ([]checklistSection, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	fieldColumn := make(map[string]int)
+	for field, aliases := range csvFieldAliases {
+		if h, ok := mapping[field]; ok {
+			idx, found := columns[strings.ToLower(h)]
+			if !found {
+				return nil, fmt.Errorf("column '%s' not found in CSV header", h)
+			}
+			fieldColumn[field] = idx
+			continue
+		}
+		for _, alias := range aliases {
+			if idx, found := columns[alias]; found {
+				fieldColumn[field] = idx
+				break
+			}
+		}
+	}
+
+	if _, ok := fieldColumn["title"]; !ok {
+		return nil, errors.New("no title column found in CSV (use --map title=<header>)")
+	}
+
+	var sections []checklistSection
+	sectionIndex := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(field string) string {
+			idx, ok := fieldColumn[field]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		item := checklistItem{
+			Title:       get("title"),
+			DueOn:       get("due"),
+			StartsOn:    get("starts"),
+			Description: get("description"),
+		}
+		if item.Title == "" {
+			continue
+		}
+
+		switch strings.ToLower(get("completed")) {
+		case "x", "y", "yes", "true", "1", "done", "completed":
+			item.Completed = true
+		}
+
+		for _, a := range strings.FieldsFunc(get("assignees"), func(r rune) bool { return r == ',' || r == ';' }) {
+			if a = strings.TrimPrefix(strings.TrimSpace(a), "@"); a != "" {
+				item.Assignees = append(item.Assignees, a)
+			}
+		}
+
+		list, group := get("todolist"), get("group")
+		idx, ok := sectionIndex[list+"\x00"+group]
+		if !ok {
+			idx = len(sections)
+			sectionIndex[list+"\x00"+group] = idx
+			sections = append(sections, checklistSection{List: list, Heading: group})
+		}
+		sections[idx].Items = append(sections[idx].Items, item)
+	}
+
+	return sections, nil
+}
+
+// textToHTML escapes plain text for a rich text field, keeping line breaks
+func textToHTML(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return strings.Join(lines, "<br>")
+}
+
+func todoImportKey(group, title string) string {
+	return normalizeTitle(group) + "|" + normalizeTitle(title)
+}
+
+func (c *TodosImportCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var todolistRef, file, mapping string
+	var all, dryRun, resume bool
+
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--file":
+			if i+1 < len(remaining) {
+				file = remaining[i+1]
+				i++
+			}
+		case "--map":
+			if i+1 < len(remaining) {
+				mapping = remaining[i+1]
+				i++
+			}
+		case "--all":
+			all = true
+		case "--dry-run":
+			dryRun = true
+		case "--resume":
+			resume = true
+		default:
+			if todolistRef == "" {
+				todolistRef = remaining[i]
+			}
+		}
+	}
+
+	if (todolistRef == "") == !all || file == "" {
+		return errors.New("usage: basecamp todos-import [project_id] <todolist>|--all --file <plan.md|plan.csv> [--map field=Header,...] [--dry-run] [--resume]")
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+	defer f.Close()
+
+	var sections []checklistSection
+	if strings.EqualFold(filepath.Ext(file), ".csv") {
+		fieldMap, err := parseCSVMapping(mapping)
+		if err != nil {
+			return err
+		}
+		sections, err = parseTodoCSV(f, fieldMap)
+		if err != nil {
+			return err
+		}
+	} else {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		if all {
+			sections = parseTodolistSections(string(data))
+		} else {
+			sections = parseChecklistSections(string(data))
+		}
+	}
+
+	if len(sections) == 0 {
+		return errors.New("no todos found (expected lines like '- [ ] Title' or CSV rows)")
+	}
+	if all {
+		for _, section := range sections {
+			if section.List == "" {
+				return fmt.Errorf("todo '%s' is not in a todolist (expected a '# Todolist' heading or todolist column)", section.Items[0].Title)
+			}
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Resolve every assignee up front so a typo fails before anything is created
+	var people []Person
+	assignees := make(map[string]int)
+	for _, section := range sections {
+		for _, item := range section.Items {
+			for _, ref := range item.Assignees {
+				if _, done := assignees[ref]; done {
+					continue
+				}
+				if people == nil {
+					if people, err = fetchPeople(cl); err != nil {
+						return err
+					}
+				}
+				person, err := resolvePerson(people, ref)
+				if err != nil {
+					return fmt.Errorf("todo '%s': %w", item.Title, err)
+				}
+				assignees[ref] = person.ID
+			}
+		}
+	}
+
+	output := TodosImportOutput{
+		Status:        "ok",
+		DryRun:        dryRun,
+		GroupsCreated: []string{},
+		Created:       []TodoImportResult{},
+		Skipped:       []string{},
+	}
+	importer := todoImporter{cl: cl, projectID: projectID, assignees: assignees, dryRun: dryRun, resume: resume, output: &output}
+
+	if !all {
+		todolistID, err := resolveTodolistID(cl, projectID, todolistRef)
+		if err != nil {
+			return err
+		}
+		output.TodolistID = todolistID
+		if err := importer.importSections(todolistID, "", sections); err != nil {
+			return err
+		}
+	} else {
+		// Lists are matched by exact title, since a partial match would
+		// put todos in the wrong list, and created when missing
+		todoset, todolists, err := fetchTodolists(cl, projectID)
+		if err != nil {
+			return err
+		}
+		listIDs := make(map[string]string)
+		for _, tl := range todolists {
+			listIDs[normalizeTitle(tl.Title)] = strconv.Itoa(tl.ID)
+		}
+
+		var lists []string
+		byList := make(map[string][]checklistSection)
+		for _, section := range sections {
+			key := normalizeTitle(section.List)
+			if _, seen := byList[key]; !seen {
+				lists = append(lists, section.List)
+			}
+			byList[key] = append(byList[key], section)
+		}
+
+		for _, list := range lists {
+			key := normalizeTitle(list)
+			todolistID, ok := listIDs[key]
+			if !ok {
+				output.TodolistsCreated = append(output.TodolistsCreated, list)
+				if !dryRun {
+					data, err := cl.Post(todoset.TodolistsURL, map[string]any{"name": list})
+					if err != nil {
+						return err
+					}
+					var created Todolist
+					if err := json.Unmarshal(data, &created); err != nil {
+						return err
+					}
+					todolistID = strconv.Itoa(created.ID)
+				}
+			}
+			if err := importer.importSections(todolistID, list, byList[key]); err != nil {
+				return err
+			}
+		}
+	}
+
+	verb := "created"
+	if dryRun {
+		verb = "would be created"
+	}
+	output.Message = fmt.Sprintf("%d todos %s, %d skipped", len(output.Created), verb, len(output.Skipped))
+	return PrintJSON(output)
+}
+
+// todoImporter creates imported todos and records what it did in output
+type todoImporter struct {
+	cl        *client.Client
+	projectID string
+	assignees map[string]int
+	dryRun    bool
+	resume    bool
+	output    *TodosImportOutput
+}
+
+// importSections creates the todos of sections in a todolist, creating
+// missing groups. list names the todolist in results when importing
+// several. todolistID is empty for a list that a dry run would create.
+func (im *todoImporter) importSections(todolistID, list string, sections []checklistSection) error {
+	cl, projectID, output := im.cl, im.projectID, im.output
+
+	var groups []TodolistGroup
+	if todolistID != "" {
+		var err error
+		if groups, err = fetchTodolistGroups(cl, projectID, todolistID); err != nil {
+			return err
+		}
+	}
+
+	groupIDs := make(map[string]int)
+	for _, g := range groups {
+		groupIDs[normalizeTitle(g.Name)] = g.ID
+	}
+
+	existing := make(map[string]bool)
+	if im.resume && todolistID != "" {
+		containers := map[string]string{"": "/buckets/" + projectID + "/todolists/" + todolistID + "/todos.json"}
+		for _, g := range groups {
+			containers[g.Name] = g.TodosURL
+		}
+		for group, todosURL := range containers {
+			todos, err := fetchTodos(cl, todosURL, true)
+			if err != nil {
+				return err
+			}
+			for _, todo := range todos {
+				existing[todoImportKey(group, todo.Content)] = true
+			}
+		}
+	}
+
+	for _, section := range sections {
+		parentID := todolistID
+
+		if section.Heading != "" {
+			groupID, ok := groupIDs[normalizeTitle(section.Heading)]
+			if !ok {
+				output.GroupsCreated = append(output.GroupsCreated, section.Heading)
+				if !im.dryRun {
+					data, err := cl.Post("/buckets/"+projectID+"/todolists/"+todolistID+"/groups.json", map[string]any{
+						"name": section.Heading,
+					})
+					if err != nil {
+						return err
+					}
+					var group TodolistGroup
+					if err := json.Unmarshal(data, &group); err != nil {
+						return err
+					}
+					groupID = group.ID
+					groupIDs[normalizeTitle(section.Heading)] = groupID
+				}
+			}
+			parentID = strconv.Itoa(groupID)
+		}
+
+		for _, item := range section.Items {
+			key := todoImportKey(section.Heading, item.Title)
+			if existing[key] {
+				output.Skipped = append(output.Skipped, item.Title)
+				continue
+			}
+
+			result := TodoImportResult{
+				Todolist:  list,
+				Content:   item.Title,
+				Group:     section.Heading,
+				Completed: item.Completed,
+			}
+
+			if !im.dryRun {
+				payload := map[string]any{
+					"content": item.Title,
+				}
+				if item.Description != "" {
+					payload["description"] = textToHTML(item.Description)
+				}
+				if item.DueOn != "" {
+					payload["due_on"] = item.DueOn
+				}
+				if item.StartsOn != "" {
+					payload["starts_on"] = item.StartsOn
+				}
+				if len(item.Assignees) > 0 {
+					ids := make([]int, len(item.Assignees))
+					for i, ref := range item.Assignees {
+						ids[i] = im.assignees[ref]
+					}
+					payload["assignee_ids"] = ids
+				}
+
+				data, err := cl.Post("/buckets/"+projectID+"/todolists/"+parentID+"/todos.json", payload)
+				if err != nil {
+					return err
+				}
+
+				var created Todo
+				if err := json.Unmarshal(data, &created); err != nil {
+					return err
+				}
+
+				if item.Completed {
+					if _, err := cl.Post(fmt.Sprintf("/buckets/%s/todos/%d/completion.json", projectID, created.ID), nil); err != nil {
+						return err
+					}
+				}
+				result.ID = created.ID
+			}
+
+			existing[key] = true
+			output.Created = append(output.Created, result)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCSVMapping(t *testing.T) {
+	got, err := parseCSVMapping("title=Task Name, due = Deadline")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"title": "Task Name", "due": "Deadline"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCSVMapping() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"title", "title=", "owner=Who"} {
+		if _, err := parseCSVMapping(bad); err == nil {
+			t.Errorf("parseCSVMapping(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseTodoCSV(t *testing.T) {
+	input := `Content,Group,Assignees,Due,Starts,Done,Notes
+Kickoff,,jane;@bob,2026-01-05,,,Agenda in doc
+Set up repo,Week 1,,,,x,
+Write spec,Week 1,jane,2026-02-01,2026-01-20,no,
+,Week 2,,,,,
+Build it,Week 2,,,,,
+`
+	sections, err := parseTodoCSV(strings.NewReader(input), map[string]string{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sections) != 3 {
+		t.Fatalf("expected 3 sections, got %d", len(sections))
+	}
+
+	kickoff := sections[0].Items[0]
+	want := checklistItem{
+		Title:       "Kickoff",
+		Assignees:   []string{"jane", "bob"},
+		DueOn:       "2026-01-05",
+		Description: "Agenda in doc",
+	}
+	if sections[0].Heading != "" || !reflect.DeepEqual(kickoff, want) {
+		t.Errorf("kickoff = %+v, want %+v", kickoff, want)
+	}
+
+	week1 := sections[1]
+	if week1.Heading != "Week 1" || len(week1.Items) != 2 {
+		t.Fatalf("unexpected week 1 section: %+v", week1)
+	}
+	if !week1.Items[0].Completed || week1.Items[1].Completed {
+		t.Error("expected only 'Set up repo' to be completed")
+	}
+	if week1.Items[1].StartsOn != "2026-01-20" {
+		t.Errorf("expected starts 2026-01-20, got %q", week1.Items[1].StartsOn)
+	}
+
+	if len(sections[2].Items) != 1 {
+		t.Errorf("expected rows without a title to be skipped, got %+v", sections[2].Items)
+	}
+}
+
+func TestParseTodoCSVMapping(t *testing.T) {
+	input := "Task Name,Deadline\nShip it,2026-03-01\n"
+
+	sections, err := parseTodoCSV(strings.NewReader(input), map[string]string{"title": "task name", "due": "Deadline"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sections[0].Items[0]; got.Title != "Ship it" || got.DueOn != "2026-03-01" {
+		t.Errorf("unexpected item: %+v", got)
+	}
+
+	if _, err := parseTodoCSV(strings.NewReader(input), map[string]string{}); err == nil {
+		t.Error("expected error when no title column is found")
+	}
+	if _, err := parseTodoCSV(strings.NewReader(input), map[string]string{"title": "Missing"}); err == nil {
+		t.Error("expected error for a mapped column that does not exist")
+	}
+}
+
+func TestTextToHTML(t *testing.T) {
+	got := textToHTML("a < b\nsecond & last")
+	want := "a &lt; b<br>second &amp; last"
+	if got != want {
+		t.Errorf("textToHTML() = %q, want %q", got, want)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"io"
 	"mime"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/rzolkos/basecamp-cli/internal/client"
 )
@@ -39,61 +43,134 @@ type UploadOutput struct {
 	Message        string `json:"message"`
 }
 
-func (c *UploadCmd) Run(args []string) error {
-	if len(args) < 1 {
-		return errors.New("file path required")
-	}
-	filePath := args[0]
-
-	// Read the file
+// uploadAttachment uploads a file to /attachments.json and returns its
+// attachable_sgid, for embedding in rich text or creating an upload. The
+// file is streamed, so its size does not matter.
+func uploadAttachment(cl *client.Client, filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return "", fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	// Get file info
 	fileInfo, err := file.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to stat file: %w", err)
-	}
-
-	// Determine content type
-	ext := filepath.Ext(filePath)
-	contentType := mime.TypeByExtension(ext)
-	if contentType == "" {
-		contentType = "application/octet-stream"
+		return "", fmt.Errorf("failed to stat file: %w", err)
 	}
-
-	// Read file contents
-	fileData, err := io.ReadAll(file)
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+	if fileInfo.IsDir() {
+		return "", fmt.Errorf("%s is a directory", filePath)
 	}
 
-	cl, err := client.New()
+	contentType, err := detectContentType(file, filePath)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Upload the file
 	fileName := filepath.Base(filePath)
-	data, err := cl.UploadFile("/attachments.json?name="+fileName, fileData, contentType, fileInfo.Size())
+	path := "/attachments.json?name=" + url.QueryEscape(fileName)
+	data, err := cl.UploadFile(path, file, contentType, fileInfo.Size(), uploadProgress(os.Stderr, fileName))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var result struct {
 		AttachableSGID string `json:"attachable_sgid"`
 	}
 	if err := json.Unmarshal(data, &result); err != nil {
+		return "", err
+	}
+	return result.AttachableSGID, nil
+}
+
+// detectContentType uses the file's extension, or sniffs its first bytes
+// when the extension is unknown, and leaves the file at its start
+func detectContentType(file io.ReadSeeker, name string) (string, error) {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType, nil
+	}
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// uploadProgress shows upload progress on w when it is a terminal, so
+// scripts reading stderr only ever see errors
+func uploadProgress(w *os.File, name string) client.UploadProgress {
+	if info, err := w.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return progressPrinter(w, name)
+}
+
+// progressPrinter redraws one line with the percentage sent, ending it
+// when the upload completes
+func progressPrinter(w io.Writer, name string) client.UploadProgress {
+	last := -1
+	return func(sent, total int64) {
+		percent := 100
+		if total > 0 {
+			percent = int(sent * 100 / total)
+		}
+		if percent == last {
+			return
+		}
+		last = percent
+
+		fmt.Fprintf(w, "\rUploading %s: %3d%% (%s of %s)", name, percent, formatByteSize(sent), formatByteSize(total))
+		if sent >= total {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// formatByteSize formats a size in bytes, KB, MB or GB
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if size < unit {
+			break
+		}
+		size, suffix = size/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", size, suffix)
+}
+
+// attachmentHTML embeds an uploaded attachment in rich text
+func attachmentHTML(sgid string) string {
+	return `<bc-attachment sgid="` + html.EscapeString(sgid) + `"></bc-attachment>`
+}
+
+func (c *UploadCmd) Run(args []string) error {
+	if len(args) < 1 {
+		return errors.New("file path required")
+	}
+	filePath := args[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	sgid, err := uploadAttachment(cl, filePath)
+	if err != nil {
 		return err
 	}
 
 	return PrintJSON(UploadOutput{
 		Status:         "ok",
-		AttachableSGID: result.AttachableSGID,
-		Message:        fmt.Sprintf("File '%s' uploaded", fileName),
+		AttachableSGID: sgid,
+		Message:        fmt.Sprintf("File '%s' uploaded", filepath.Base(filePath)),
 	})
 }
 
@@ -121,17 +198,22 @@ func (c *UploadsCmd) Run(args []string) error {
 		return err
 	}
 
-	if len(remaining) < 1 {
-		return errors.New("vault_id required")
+	var vaultRef string
+	if len(remaining) > 0 {
+		vaultRef = remaining[0]
 	}
-	vaultID := remaining[0]
 
 	cl, err := client.New()
 	if err != nil {
 		return err
 	}
 
-	data, err := cl.Get("/buckets/" + projectID + "/vaults/" + vaultID + "/uploads.json")
+	vault, err := resolveVault(cl, projectID, vaultRef)
+	if err != nil {
+		return err
+	}
+
+	data, err := cl.Get("/buckets/" + projectID + "/vaults/" + strconv.Itoa(vault.ID) + "/uploads.json")
 	if err != nil {
 		return err
 	}
@@ -141,13 +223,12 @@ func (c *UploadsCmd) Run(args []string) error {
 		return err
 	}
 
-	var pID, vID int
+	var pID int
 	fmt.Sscanf(projectID, "%d", &pID)
-	fmt.Sscanf(vaultID, "%d", &vID)
 
 	output := UploadsOutput{
 		ProjectID: pID,
-		VaultID:   vID,
+		VaultID:   vault.ID,
 		Uploads:   make([]UploadBrief, len(uploads)),
 	}
 
@@ -165,6 +246,199 @@ func (c *UploadsCmd) Run(args []string) error {
 	return PrintJSON(output)
 }
 
+// UploadCreateCmd stores a file in a folder
+type UploadCreateCmd struct{}
+
+type UploadCreateOutput struct {
+	Status  string `json:"status"`
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	VaultID int    `json:"vault_id"`
+	URL     string `json:"url"`
+	Message string `json:"message"`
+}
+
+func (c *UploadCreateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var positional []string
+	var description, name string
+	var md markdownFlags
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--description":
+			if i+1 < len(remaining) {
+				description = remaining[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(remaining) {
+				name = remaining[i+1]
+				i++
+			}
+		default:
+			if !md.parse(remaining[i]) {
+				positional = append(positional, remaining[i])
+			}
+		}
+	}
+
+	if len(positional) < 2 {
+		return errors.New("usage: basecamp upload-create [project_id] <vault> <file> [--description <text>] [--name <name>] [--markdown]")
+	}
+	vaultRef, filePath := positional[0], positional[1]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	// Resolve the folder before uploading, so a bad path fails fast
+	vault, err := resolveVault(cl, projectID, vaultRef)
+	if err != nil {
+		return err
+	}
+
+	sgid, err := uploadAttachment(cl, filePath)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"attachable_sgid": sgid}
+	if description != "" {
+		payload["description"] = md.convert(description)
+	}
+	if name != "" {
+		payload["base_name"] = name
+	}
+
+	data, err := cl.Post("/buckets/"+projectID+"/vaults/"+strconv.Itoa(vault.ID)+"/uploads.json", payload)
+	if err != nil {
+		return err
+	}
+
+	var upload Upload
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return err
+	}
+
+	return PrintJSON(UploadCreateOutput{
+		Status:  "ok",
+		ID:      upload.ID,
+		Title:   upload.Title,
+		VaultID: vault.ID,
+		URL:     upload.AppURL,
+		Message: fmt.Sprintf("File '%s' stored in '%s'", upload.Title, vault.Title),
+	})
+}
+
+// UploadUpdateCmd renames an upload or changes its description
+type UploadUpdateCmd struct{}
+
+func (c *UploadUpdateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp upload-update [project_id] <upload_id> [--description <text>] [--name <name>] [--markdown]")
+	}
+	uploadID := remaining[0]
+
+	// Pointers distinguish "not given" from "set to empty"
+	var description, name *string
+	var md markdownFlags
+	for i := 1; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--description":
+			if i+1 < len(remaining) {
+				description = &remaining[i+1]
+				i++
+			}
+		case "--name":
+			if i+1 < len(remaining) {
+				name = &remaining[i+1]
+				i++
+			}
+		default:
+			md.parse(remaining[i])
+		}
+	}
+
+	if description == nil && name == nil {
+		return errors.New("at least one of --description or --name required")
+	}
+	if name != nil && *name == "" {
+		return errors.New("--name cannot be empty")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{}
+	if description != nil {
+		payload["description"] = md.convert(*description)
+	}
+	if name != nil {
+		payload["base_name"] = *name
+	}
+
+	data, err := cl.Put("/buckets/"+projectID+"/uploads/"+uploadID+".json", payload)
+	if err != nil {
+		return err
+	}
+
+	var upload Upload
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":  "ok",
+		"id":      upload.ID,
+		"title":   upload.Title,
+		"url":     upload.AppURL,
+		"message": "Upload updated",
+	})
+}
+
+// UploadTrashCmd moves an upload to the trash
+type UploadTrashCmd struct{}
+
+func (c *UploadTrashCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) < 1 {
+		return errors.New("usage: basecamp upload-trash [project_id] <upload_id>")
+	}
+	uploadID := remaining[0]
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.Put("/buckets/"+projectID+"/recordings/"+uploadID+"/status/trashed.json", nil)
+	if err != nil {
+		return err
+	}
+
+	return PrintJSON(map[string]any{
+		"status":    "ok",
+		"upload_id": uploadID,
+		"message":   "Upload moved to trash",
+	})
+}
+
 // UploadViewCmd views a single upload
 type UploadViewCmd struct{}
 
@@ -239,7 +513,7 @@ func (c *UploadViewCmd) Run(args []string) error {
 	}
 
 	if showComments && upload.CommentsURL != "" {
-		comments, err := fetchComments(cl, upload.CommentsURL)
+		comments, err := fetchComments(cl, upload.CommentsURL, bodyText)
 		if err != nil {
 			return err
 		}
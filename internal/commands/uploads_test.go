@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"notes.pdf", "%PDF-1.4", "application/pdf"},
+		{"README", "plain words", "text/plain; charset=utf-8"},
+		{"image.unknownext", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"blob", "", "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := strings.NewReader(tt.content)
+			got, err := detectContentType(file, tt.name)
+			if err != nil {
+				t.Fatalf("detectContentType() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("detectContentType() = %q, want %q", got, tt.want)
+			}
+			// The whole file must still be uploaded
+			if rest, _ := io.ReadAll(file); string(rest) != tt.content {
+				t.Errorf("file not rewound, %q left", rest)
+			}
+		})
+	}
+}
+
+func TestProgressPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	progress := progressPrinter(&buf, "video.mp4")
+
+	progress(512, 2048)
+	progress(520, 2048) // same percentage, not redrawn
+	progress(2048, 2048)
+
+	want := "\rUploading video.mp4:  25% (512 B of 2.0 KB)" +
+		"\rUploading video.mp4: 100% (2.0 KB of 2.0 KB)\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		0:                  "0 B",
+		1023:               "1023 B",
+		1536:               "1.5 KB",
+		5 * 1024 * 1024:    "5.0 MB",
+		3 << 30:            "3.0 GB",
+		2048 * 1024 * 1024: "2.0 GB",
+	}
+	for n, want := range tests {
+		if got := formatByteSize(n); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a&b #1.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4 report"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/attachments.json" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		// Unescaped, & would end the name and # would start a fragment
+		if got := r.URL.Query().Get("name"); got != "a&b #1.pdf" {
+			t.Errorf("name = %q (query %q)", got, r.URL.RawQuery)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/pdf" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		if data, _ := io.ReadAll(r.Body); string(data) != "%PDF-1.4 report" {
+			t.Errorf("body = %q", data)
+		}
+		w.Write([]byte(`{"attachable_sgid":"sgid-1"}`))
+	}))
+	defer srv.Close()
+
+	sgid, err := uploadAttachment(client.NewWithBaseURL(srv.URL, "token"), path)
+	if err != nil {
+		t.Fatalf("uploadAttachment() error: %v", err)
+	}
+	if sgid != "sgid-1" {
+		t.Errorf("uploadAttachment() = %q, want sgid-1", sgid)
+	}
+
+	if _, err := uploadAttachment(client.NewWithBaseURL(srv.URL, "token"), filepath.Dir(path)); err == nil {
+		t.Error("expected an error for a directory")
+	}
+}
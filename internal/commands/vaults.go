@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+// resolveVault finds a folder by ID or by a path of folder names from the
+// project's top-level vault, like "Design/Logos". A path may also start
+// from a folder ID, like "123/Logos", but a top-level folder whose name is
+// a number, like "2024", is matched by name first. An empty ref is the
+// top-level vault.
+func resolveVault(cl *client.Client, projectID, ref string) (Vault, error) {
+	var segments []string
+	if ref = strings.Trim(ref, "/"); ref != "" {
+		segments = strings.Split(ref, "/")
+	}
+
+	_, vault, err := fetchVault(cl, projectID)
+	if err != nil {
+		return Vault{}, err
+	}
+
+	if len(segments) > 0 {
+		if _, err := strconv.Atoi(segments[0]); err == nil {
+			children, err := fetchChildVaults(cl, vault)
+			if err != nil {
+				return Vault{}, err
+			}
+			if !hasVaultTitle(children, segments[0]) {
+				data, err := cl.Get("/buckets/" + projectID + "/vaults/" + segments[0] + ".json")
+				if err != nil {
+					return Vault{}, err
+				}
+				if err := json.Unmarshal(data, &vault); err != nil {
+					return Vault{}, err
+				}
+				segments = segments[1:]
+			}
+		}
+	}
+
+	for _, name := range segments {
+		children, err := fetchChildVaults(cl, vault)
+		if err != nil {
+			return Vault{}, err
+		}
+		names := make([]string, len(children))
+		for i, child := range children {
+			names[i] = child.Title
+		}
+		idx, err := matchByName("folder", name, names)
+		if err != nil {
+			return Vault{}, err
+		}
+		vault = children[idx]
+	}
+
+	return vault, nil
+}
+
+// hasVaultTitle reports whether one of the vaults is named title
+func hasVaultTitle(vaults []Vault, title string) bool {
+	for _, v := range vaults {
+		if strings.EqualFold(v.Title, title) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchChildVaults gets the folders directly inside a vault
+func fetchChildVaults(cl *client.Client, vault Vault) ([]Vault, error) {
+	if vault.VaultsURL == "" {
+		return nil, nil
+	}
+	pages, err := cl.GetAll(vault.VaultsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	vaults := make([]Vault, len(pages))
+	for i, vaultJSON := range pages {
+		if err := json.Unmarshal(vaultJSON, &vaults[i]); err != nil {
+			return nil, err
+		}
+	}
+	return vaults, nil
+}
+
+// VaultsCmd lists the folders in a vault
+type VaultsCmd struct{}
+
+type VaultsOutput struct {
+	ProjectID string       `json:"project_id"`
+	VaultID   int          `json:"vault_id"`
+	Title     string       `json:"title"`
+	Vaults    []VaultBrief `json:"vaults"`
+}
+
+type VaultBrief struct {
+	ID             int    `json:"id"`
+	Title          string `json:"title"`
+	DocumentsCount int    `json:"documents_count"`
+	UploadsCount   int    `json:"uploads_count"`
+	VaultsCount    int    `json:"vaults_count"`
+	URL            string `json:"url"`
+}
+
+func (c *VaultsCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var parent string
+	for i := 0; i < len(remaining); i++ {
+		if remaining[i] == "--parent" && i+1 < len(remaining) {
+			parent = remaining[i+1]
+			i++
+		}
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vault, err := resolveVault(cl, projectID, parent)
+	if err != nil {
+		return err
+	}
+
+	children, err := fetchChildVaults(cl, vault)
+	if err != nil {
+		return err
+	}
+
+	output := VaultsOutput{
+		ProjectID: projectID,
+		VaultID:   vault.ID,
+		Title:     vault.Title,
+		Vaults:    make([]VaultBrief, len(children)),
+	}
+	for i, v := range children {
+		output.Vaults[i] = VaultBrief{
+			ID:             v.ID,
+			Title:          v.Title,
+			DocumentsCount: v.DocumentsCount,
+			UploadsCount:   v.UploadsCount,
+			VaultsCount:    v.VaultsCount,
+			URL:            v.AppURL,
+		}
+	}
+
+	return PrintJSON(output)
+}
+
+// VaultCreateCmd creates a folder
+type VaultCreateCmd struct{}
+
+type VaultCreateOutput struct {
+	Status   string `json:"status"`
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	ParentID int    `json:"parent_id"`
+	URL      string `json:"url"`
+	Message  string `json:"message"`
+}
+
+func (c *VaultCreateCmd) Run(args []string) error {
+	projectID, remaining, err := getProjectID(args)
+	if err != nil {
+		return err
+	}
+
+	var title, parent string
+	for i := 0; i < len(remaining); i++ {
+		switch remaining[i] {
+		case "--parent":
+			if i+1 < len(remaining) {
+				parent = remaining[i+1]
+				i++
+			}
+		default:
+			if title == "" {
+				title = remaining[i]
+			}
+		}
+	}
+
+	// "Design/Logos" creates Logos inside Design
+	if idx := strings.LastIndex(strings.TrimRight(title, "/"), "/"); idx != -1 {
+		if parent != "" {
+			return errors.New("give the parent folder either in the path or with --parent, not both")
+		}
+		parent, title = title[:idx], strings.Trim(title[idx+1:], "/")
+	}
+	if title == "" {
+		return errors.New("usage: basecamp vault-create [project_id] <title|parent/title> [--parent <vault>]")
+	}
+
+	cl, err := client.New()
+	if err != nil {
+		return err
+	}
+
+	vault, err := resolveVault(cl, projectID, parent)
+	if err != nil {
+		return err
+	}
+
+	vaultsURL := vault.VaultsURL
+	// Convert from full URL to path
+	if idx := strings.Index(vaultsURL, "/buckets/"); idx != -1 {
+		vaultsURL = vaultsURL[idx:]
+	}
+
+	data, err := cl.Post(vaultsURL, map[string]string{"title": title})
+	if err != nil {
+		return err
+	}
+
+	var created Vault
+	if err := json.Unmarshal(data, &created); err != nil {
+		return err
+	}
+
+	return PrintJSON(VaultCreateOutput{
+		Status:   "ok",
+		ID:       created.ID,
+		Title:    created.Title,
+		ParentID: vault.ID,
+		URL:      created.AppURL,
+		Message:  fmt.Sprintf("Folder '%s' created in '%s'", created.Title, vault.Title),
+	})
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rzolkos/basecamp-cli/internal/client"
+)
+
+func TestResolveVault(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vault := func(id int, title string) string {
+			return fmt.Sprintf(`{"id":%d,"title":%q,"vaults_url":"%s/buckets/1/vaults/%d/vaults.json"}`, id, title, srv.URL, id)
+		}
+		switch r.URL.Path {
+		case "/projects/1.json":
+			fmt.Fprintf(w, `{"id":1,"dock":[{"name":"vault","url":"%s/buckets/1/vaults/10.json"}]}`, srv.URL)
+		case "/buckets/1/vaults/10.json":
+			fmt.Fprint(w, vault(10, "Docs & Files"))
+		case "/buckets/1/vaults/10/vaults.json":
+			fmt.Fprintf(w, "[%s,%s]", vault(20, "2024"), vault(40, "Design"))
+		case "/buckets/1/vaults/20/vaults.json":
+			fmt.Fprintf(w, "[%s]", vault(30, "Q1"))
+		case "/buckets/1/vaults/30.json":
+			fmt.Fprint(w, vault(30, "Q1"))
+		case "/buckets/1/vaults/30/vaults.json", "/buckets/1/vaults/40/vaults.json":
+			fmt.Fprint(w, "[]")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cl := client.NewWithBaseURL(srv.URL, "token")
+	tests := []struct {
+		ref    string
+		wantID int
+	}{
+		{"", 10},
+		{"Design", 40},
+		{"design/", 40},
+		{"2024", 20},    // a folder named like a number
+		{"2024/Q1", 30}, // and a path through it
+		{"30", 30},      // an ID when no top-level folder has that name
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			vault, err := resolveVault(cl, "1", tt.ref)
+			if err != nil {
+				t.Fatalf("resolveVault(%q) error: %v", tt.ref, err)
+			}
+			if vault.ID != tt.wantID {
+				t.Errorf("resolveVault(%q) = %d, want %d", tt.ref, vault.ID, tt.wantID)
+			}
+		})
+	}
+
+	if _, err := resolveVault(cl, "1", "Design/Logos"); err == nil {
+		t.Error("expected an error for a missing folder")
+	}
+}
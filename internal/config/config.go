@@ -24,6 +24,16 @@ type Config struct {
 	ClientSecret string `json:"client_secret"`
 	AccountID    string `json:"account_id"`
 	RedirectURI  string `json:"redirect_uri"`
+
+	// Checklists are named markdown checklists used by step-import --template
+	Checklists map[string][]string `json:"checklists,omitempty"`
+
+	// Chatbots maps names to chatbot line URLs used by chatbot-post --bot
+	Chatbots map[string]string `json:"chatbots,omitempty"`
+
+	// Markdown converts --content from markdown by default; --no-markdown
+	// sends it as HTML
+	Markdown bool `json:"markdown,omitempty"`
 }
 
 type TokenData struct {
@@ -19,6 +19,8 @@ func TestConfigSaveLoad(t *testing.T) {
 		ClientSecret: "test-client-secret",
 		AccountID:    "12345",
 		RedirectURI:  "http://localhost:3002/callback",
+		Chatbots:     map[string]string{"ci": "https://3.basecampapi.com/12345/integrations/key/buckets/1/chats/2/lines.json"},
+		Markdown:     true,
 	}
 
 	// Save config
@@ -47,6 +49,12 @@ func TestConfigSaveLoad(t *testing.T) {
 	if loaded.AccountID != cfg.AccountID {
 		t.Errorf("AccountID = %v, want %v", loaded.AccountID, cfg.AccountID)
 	}
+	if loaded.Chatbots["ci"] != cfg.Chatbots["ci"] {
+		t.Errorf("Chatbots[ci] = %v, want %v", loaded.Chatbots["ci"], cfg.Chatbots["ci"])
+	}
+	if !loaded.Markdown {
+		t.Error("Markdown = false, want true")
+	}
 }
 
 func TestConfigNotFound(t *testing.T) {
@@ -153,3 +161,61 @@ func TestTokenExpired(t *testing.T) {
 		t.Errorf("LoadToken() error = %v, want %v", err, ErrTokenExpired)
 	}
 }
+
+func TestConfigChecklists(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.Setenv("XDG_CONFIG_HOME", tmpDir)
+	defer os.Unsetenv("XDG_CONFIG_HOME")
+
+	cfg := &Config{
+		AccountID: "12345",
+		Checklists: map[string][]string{
+			"dod": {"- [ ] Tests pass", "- [ ] Docs updated"},
+		},
+	}
+
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(loaded.Checklists["dod"]) != 2 {
+		t.Errorf("Checklists[dod] = %v, want 2 lines", loaded.Checklists["dod"])
+	}
+}
+
+func TestPeopleCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "basecamp", "people.json")
+
+	cache, err := LoadPeopleCache(path)
+	if err != nil {
+		t.Fatalf("LoadPeopleCache() error = %v", err)
+	}
+	if cache.Fresh(time.Now()) {
+		t.Error("empty cache should not be fresh")
+	}
+
+	cache.UpdatedAt = time.Now()
+	cache.People = []CachedPerson{{ID: 1, Name: "Jane Doe", EmailAddress: "jane@example.com", AttachableSGID: "sgid-1"}}
+	if err := SavePeopleCache(path, cache); err != nil {
+		t.Fatalf("SavePeopleCache() error = %v", err)
+	}
+
+	loaded, err := LoadPeopleCache(path)
+	if err != nil {
+		t.Fatalf("LoadPeopleCache() error = %v", err)
+	}
+	if len(loaded.People) != 1 || loaded.People[0] != cache.People[0] {
+		t.Errorf("People = %+v, want %+v", loaded.People, cache.People)
+	}
+	if !loaded.Fresh(time.Now()) {
+		t.Error("cache should be fresh right after saving")
+	}
+	if loaded.Fresh(time.Now().Add(PeopleCacheTTL + time.Minute)) {
+		t.Error("cache should expire after PeopleCacheTTL")
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PeopleCacheTTL is how long cached people are used before they are
+// fetched again
+const PeopleCacheTTL = 24 * time.Hour
+
+// PeopleCache holds the people @mentions are resolved against, so
+// composing content does not fetch every person each time
+type PeopleCache struct {
+	UpdatedAt time.Time      `json:"updated_at"`
+	People    []CachedPerson `json:"people"`
+}
+
+// CachedPerson is the part of a person needed to mention them
+type CachedPerson struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	EmailAddress   string `json:"email_address"`
+	AttachableSGID string `json:"attachable_sgid"`
+}
+
+func PeopleCacheFile() string {
+	return filepath.Join(dataDir(), "people.json")
+}
+
+// LoadPeopleCache reads the people cache, returning an empty cache if it
+// does not exist yet
+func LoadPeopleCache(path string) (*PeopleCache, error) {
+	cache := &PeopleCache{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cache, nil
+}
+
+// Fresh reports whether the cache was updated within PeopleCacheTTL
+func (c *PeopleCache) Fresh(now time.Time) bool {
+	return !c.UpdatedAt.IsZero() && now.Sub(c.UpdatedAt) < PeopleCacheTTL
+}
+
+// SavePeopleCache writes the people cache atomically
+func SavePeopleCache(path string, cache *PeopleCache) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RecurringRule describes a todo that is created on a schedule
+type RecurringRule struct {
+	Name        string
+	Project     string
+	Todolist    string
+	Group       string
+	RRule       string
+	Start       string
+	Content     string
+	Description string
+	Assignees   []string
+	DueOffset   string
+}
+
+// RecurringRuleState records what has been created for a rule
+type RecurringRuleState struct {
+	LastRun string `json:"last_run,omitempty"`
+
+	// Issued maps occurrence dates to the IDs of the todos created for them
+	Issued map[string]int `json:"issued"`
+}
+
+// RecurringState is the local record of recurring todos already created
+type RecurringState struct {
+	Rules map[string]*RecurringRuleState `json:"rules"`
+}
+
+func RecurringFile() string {
+	return filepath.Join(configDir(), "recurring.yml")
+}
+
+func RecurringStateFile() string {
+	return filepath.Join(dataDir(), "recurring-state.json")
+}
+
+// LoadRecurringRules reads recurring todo rules from a YAML file like:
+//
+//	rules:
+//	  - name: weekly-ops
+//	    project: 12345
+//	    todolist: Ops
+//	    rrule: FREQ=WEEKLY;BYDAY=MO
+//	    start: 2026-01-05
+//	    content: "Ops review {{date}}"
+//	    assignees: [jane, bob]
+//	    due_offset: 2d
+//
+// Only this subset of YAML is supported.
+func LoadRecurringRules(path string) ([]RecurringRule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rules, err := ParseRecurringRules(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseRecurringRules parses the rules format read by LoadRecurringRules
+func ParseRecurringRules(r io.Reader) ([]RecurringRule, error) {
+	var rules []RecurringRule
+	var current *RecurringRule
+	ruleIndent := -1
+	listKey := ""
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		raw := strings.TrimRight(scanner.Text(), " \t\r")
+		line := strings.TrimLeft(raw, " ")
+		indent := len(raw) - len(line)
+
+		// Skip comments and empty lines
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if indent == 0 && line == "rules:" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "- ") || line == "-" {
+			item := strings.TrimSpace(strings.TrimPrefix(line, "-"))
+
+			// An item of a block list such as "assignees:" followed by "- jane"
+			if current != nil && listKey != "" && indent > ruleIndent {
+				if err := current.set(listKey, item, true); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNum, err)
+				}
+				continue
+			}
+
+			rules = append(rules, RecurringRule{})
+			current = &rules[len(rules)-1]
+			ruleIndent = indent
+			listKey = ""
+			if item == "" {
+				continue
+			}
+			line = item
+		} else if current == nil || indent <= ruleIndent {
+			return nil, fmt.Errorf("line %d: expected a rule starting with '- '", lineNum)
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected 'key: value'", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		listKey = ""
+		if value == "" && key == "assignees" {
+			listKey = key
+			continue
+		}
+
+		if err := current.set(key, value, false); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d: name required", i+1)
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("rule '%s' is defined more than once", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		required := []struct{ field, value string }{
+			{"project", rule.Project},
+			{"todolist", rule.Todolist},
+			{"rrule", rule.RRule},
+			{"start", rule.Start},
+			{"content", rule.Content},
+		}
+		for _, r := range required {
+			if r.value == "" {
+				return nil, fmt.Errorf("rule '%s': %s required", rule.Name, r.field)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func (r *RecurringRule) set(key, value string, listItem bool) error {
+	value, err := parseScalar(value)
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case "name":
+		r.Name = value
+	case "project":
+		r.Project = value
+	case "todolist":
+		r.Todolist = value
+	case "group":
+		r.Group = value
+	case "rrule":
+		r.RRule = value
+	case "start":
+		r.Start = value
+	case "content":
+		r.Content = value
+	case "description":
+		r.Description = value
+	case "due_offset":
+		r.DueOffset = value
+	case "assignees":
+		if listItem {
+			r.Assignees = append(r.Assignees, value)
+			return nil
+		}
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		for _, a := range strings.Split(value, ",") {
+			if a, err = parseScalar(strings.TrimSpace(a)); err != nil {
+				return err
+			}
+			if a != "" {
+				r.Assignees = append(r.Assignees, a)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown key '%s'", key)
+	}
+	return nil
+}
+
+// parseScalar unquotes a YAML scalar, or strips a trailing comment from an
+// unquoted one
+func parseScalar(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("invalid quoted value %s", value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// LoadRecurringState reads the recurring state file, returning an empty
+// state if it does not exist yet
+func LoadRecurringState(path string) (*RecurringState, error) {
+	state := &RecurringState{Rules: make(map[string]*RecurringRuleState)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if state.Rules == nil {
+		state.Rules = make(map[string]*RecurringRuleState)
+	}
+	return state, nil
+}
+
+// Rule returns the state for a rule, creating it if needed
+func (s *RecurringState) Rule(name string) *RecurringRuleState {
+	rs, ok := s.Rules[name]
+	if !ok {
+		rs = &RecurringRuleState{}
+		s.Rules[name] = rs
+	}
+	if rs.Issued == nil {
+		rs.Issued = make(map[string]int)
+	}
+	return rs
+}
+
+// SaveRecurringState writes the state file atomically so an interrupted
+// run never leaves it half written
+func SaveRecurringState(path string, state *RecurringState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
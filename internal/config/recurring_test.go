@@ -0,0 +1,134 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRecurringRules(t *testing.T) {
+	input := `# Ops checklists
+rules:
+  - name: weekly-ops
+    project: 12345
+    todolist: Ops   # by name
+    rrule: FREQ=WEEKLY;BYDAY=MO
+    start: 2026-01-05
+    content: "Ops review: {{date}}"
+    assignees: [jane, "Bob Smith"]
+    due_offset: 2d
+
+  - name: monthly-invoices
+    project: '12345'
+    todolist: 678
+    group: Finance
+    rrule: FREQ=MONTHLY;BYMONTHDAY=1
+    start: 2026-01-01
+    content: 'Send invoices for {{month}} # not a comment'
+    assignees:
+      - jane@example.com
+      - bob
+`
+	rules, err := ParseRecurringRules(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseRecurringRules() error = %v", err)
+	}
+
+	want := []RecurringRule{
+		{
+			Name:      "weekly-ops",
+			Project:   "12345",
+			Todolist:  "Ops",
+			RRule:     "FREQ=WEEKLY;BYDAY=MO",
+			Start:     "2026-01-05",
+			Content:   "Ops review: {{date}}",
+			Assignees: []string{"jane", "Bob Smith"},
+			DueOffset: "2d",
+		},
+		{
+			Name:      "monthly-invoices",
+			Project:   "12345",
+			Todolist:  "678",
+			Group:     "Finance",
+			RRule:     "FREQ=MONTHLY;BYMONTHDAY=1",
+			Start:     "2026-01-01",
+			Content:   "Send invoices for {{month}} # not a comment",
+			Assignees: []string{"jane@example.com", "bob"},
+		},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("ParseRecurringRules() =\n%+v\nwant\n%+v", rules, want)
+	}
+}
+
+func TestParseRecurringRulesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			input:   "- name: a\n  colour: red\n",
+			wantErr: "line 2: unknown key 'colour'",
+		},
+		{
+			name:    "missing field",
+			input:   "- name: a\n  project: 1\n  todolist: 2\n  start: 2026-01-01\n  content: x\n",
+			wantErr: "rule 'a': rrule required",
+		},
+		{
+			name:    "duplicate name",
+			input:   "- name: a\n  project: 1\n  todolist: 2\n  rrule: FREQ=DAILY\n  start: 2026-01-01\n  content: x\n- name: a\n",
+			wantErr: "rule 'a' is defined more than once",
+		},
+		{
+			name:    "key outside rule",
+			input:   "name: a\n",
+			wantErr: "line 1: expected a rule",
+		},
+		{
+			name:    "bad quoting",
+			input:   "- name: \"a\n",
+			wantErr: "line 1: invalid quoted value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRecurringRules(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseRecurringRules() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecurringStateSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	state, err := LoadRecurringState(path)
+	if err != nil {
+		t.Fatalf("LoadRecurringState() on missing file error = %v", err)
+	}
+	if len(state.Rules) != 0 {
+		t.Errorf("expected empty state, got %+v", state.Rules)
+	}
+
+	rs := state.Rule("weekly-ops")
+	rs.LastRun = "2026-01-05"
+	rs.Issued["2026-01-05"] = 42
+
+	if err := SaveRecurringState(path, state); err != nil {
+		t.Fatalf("SaveRecurringState() error = %v", err)
+	}
+
+	loaded, err := LoadRecurringState(path)
+	if err != nil {
+		t.Fatalf("LoadRecurringState() error = %v", err)
+	}
+	if got := loaded.Rule("weekly-ops"); got.LastRun != "2026-01-05" || got.Issued["2026-01-05"] != 42 {
+		t.Errorf("loaded state = %+v", got)
+	}
+}